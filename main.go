@@ -47,6 +47,13 @@ func main() {
 		ArgsUsage: "<hostname>",
 		HideHelp:  true,
 
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:  "world",
+				Usage: "game-specific support to use (achaea or generic), instead of going by the address",
+			},
+		},
+
 		Authors: []*cli.Author{
 			{
 				Name:  "Tobias Sjösten",
@@ -64,7 +71,12 @@ func main() {
 				return err
 			}
 
-			return run(address)
+			// Check the world before connecting to play it.
+			if err := world.ValidateName(c.String("world")); err != nil {
+				return err
+			}
+
+			return run(address, c.String("world"))
 		},
 	}
 
@@ -95,7 +107,7 @@ func address(host string) (string, error) {
 	return fmt.Sprintf("%s:%d", host, defaultPort), nil
 }
 
-func run(address string) error {
+func run(address, worldName string) error {
 	ctx := context.Background()
 
 	ctx, err := ctxDirs(ctx)
@@ -103,6 +115,11 @@ func run(address string) error {
 		return err
 	}
 
+	config, err := config()
+	if err != nil {
+		return err
+	}
+
 	client, err := client(address)
 	if err != nil {
 		return err
@@ -113,11 +130,34 @@ func run(address string) error {
 		return err
 	}
 
-	engine := world.NewEngine(client, ui, address)
+	if err := ui.SetLayout(config.Layout); err != nil {
+		return err
+	}
+
+	theme, err := tui.LoadTheme(config.Theme)
+	if err != nil {
+		return err
+	}
+
+	ui.SetTheme(theme)
+
+	engine, err := world.NewEngine(client, ui, config, address, worldName)
+	if err != nil {
+		return err
+	}
 
 	return engine.Run(ctx)
 }
 
+func config() (*pkg.Config, error) {
+	dir, err := os.UserHomeDir()
+	if err != nil {
+		return nil, fmt.Errorf("failed acquiring home directory: %w", err)
+	}
+
+	return pkg.LoadConfig(dir + "/nogfx/config.json")
+}
+
 func client(address string) (pkg.Client, error) {
 	if address == "example.com:23" {
 		return &mock.ClientMock{
@@ -0,0 +1,198 @@
+package ansi
+
+import (
+	"bytes"
+)
+
+// Kind is the type of a Token.
+type Kind int
+
+// These are the known kinds of tokens.
+const (
+	// Text is plain text, to be shown as is.
+	Text Kind = iota
+
+	// CSI is a Control Sequence Introducer sequence, like "\033[31m".
+	CSI
+
+	// OSC is an Operating System Command, like "\033]0;title\007".
+	OSC
+
+	// Escape is any other escape sequence, like "\033(B".
+	Escape
+)
+
+const (
+	esc = 0x1b
+	bel = 0x07
+)
+
+// Token is a piece of text, or an escape sequence, found when parsing data.
+type Token struct {
+	Kind Kind
+
+	// Data is the text, the parameters of a CSI sequence, the command of
+	// an OSC sequence or the intermediate bytes of other escapes.
+	Data []byte
+
+	// Final is the byte terminating CSI and other escape sequences, e.g.
+	// 'm' for SGR sequences.
+	Final byte
+}
+
+// Parse splits data into text and escape sequences. Sequences left
+// unterminated at the end of the data are dropped.
+func Parse(data []byte) []Token {
+	tokens := []Token{}
+	text := []byte{}
+
+	flush := func() {
+		if len(text) > 0 {
+			tokens = append(tokens, Token{Kind: Text, Data: text})
+			text = []byte{}
+		}
+	}
+
+	for i := 0; i < len(data); {
+		if data[i] != esc {
+			text = append(text, data[i])
+			i++
+
+			continue
+		}
+
+		flush()
+
+		token, n := parseEscape(data[i:])
+		if n == 0 {
+			break
+		}
+
+		if token != nil {
+			tokens = append(tokens, *token)
+		}
+
+		i += n
+	}
+
+	flush()
+
+	return tokens
+}
+
+// parseEscape parses the escape sequence at the beginning of the data and
+// returns it, along with its length. A nil token with a positive length is a
+// sequence that should be skipped and a zero length means it's unterminated.
+func parseEscape(data []byte) (*Token, int) {
+	if len(data) < 2 {
+		return nil, 0
+	}
+
+	switch data[1] {
+	case '[':
+		return parseCSI(data)
+
+	case ']':
+		n, end := stringEnd(data)
+		if n == 0 {
+			return nil, 0
+		}
+
+		return &Token{Kind: OSC, Data: data[2:end]}, n
+
+	// Device Control Strings, Start of String, Privacy Messages and
+	// Application Program Commands are terminated like OSC but are of no
+	// interest, so they're skipped.
+	case 'P', 'X', '^', '_':
+		n, _ := stringEnd(data)
+
+		return nil, n
+	}
+
+	// Other escapes are intermediate bytes followed by a final byte.
+	for i := 1; i < len(data); i++ {
+		switch b := data[i]; {
+		case b >= 0x20 && b <= 0x2f:
+			continue
+
+		case b >= 0x30 && b <= 0x7e:
+			return &Token{Kind: Escape, Data: data[1:i], Final: b}, i + 1
+		}
+
+		// Anything else is malformed, so we skip the escape byte only.
+		return nil, 1
+	}
+
+	return nil, 0
+}
+
+// parseCSI parses a "\033[" sequence of parameter bytes, intermediate bytes
+// and a final byte.
+func parseCSI(data []byte) (*Token, int) {
+	for i := 2; i < len(data); i++ {
+		switch b := data[i]; {
+		case b >= 0x20 && b <= 0x3f:
+			continue
+
+		case b >= 0x40 && b <= 0x7e:
+			return &Token{Kind: CSI, Data: data[2:i], Final: b}, i + 1
+		}
+
+		// Anything else is malformed, so we skip what we've read.
+		return nil, i
+	}
+
+	return nil, 0
+}
+
+// stringEnd finds the end of a string sequence, like OSC, terminated by BEL or
+// ST ("\033\\"). It returns the length of the sequence and where its content
+// ends, or zeroes if it's unterminated.
+func stringEnd(data []byte) (int, int) {
+	for i := 2; i < len(data); i++ {
+		if data[i] == bel {
+			return i + 1, i
+		}
+
+		if data[i] == esc && i+1 < len(data) && data[i+1] == '\\' {
+			return i + 2, i
+		}
+	}
+
+	return 0, 0
+}
+
+// Clean removes all escape sequences from the data, leaving only text.
+func Clean(data []byte) []byte {
+	clean := []byte{}
+
+	for _, token := range Parse(data) {
+		if token.Kind == Text {
+			clean = append(clean, token.Data...)
+		}
+	}
+
+	return clean
+}
+
+// Hyperlink reads an OSC 8 hyperlink from the command of an OSC token. It
+// returns the URL and its optional ID, with an empty URL ending a link, and
+// whether the command was a hyperlink at all.
+func Hyperlink(command []byte) (string, string, bool) {
+	rest, ok := bytes.CutPrefix(command, []byte("8;"))
+	if !ok {
+		return "", "", false
+	}
+
+	params, url, _ := bytes.Cut(rest, []byte{';'})
+
+	id := ""
+
+	for _, param := range bytes.Split(params, []byte{':'}) {
+		if value, ok := bytes.CutPrefix(param, []byte("id=")); ok {
+			id = string(value)
+		}
+	}
+
+	return string(url), id, true
+}
@@ -0,0 +1,176 @@
+package ansi_test
+
+import (
+	"testing"
+
+	"github.com/tobiassjosten/nogfx/pkg/ansi"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParse(t *testing.T) {
+	tcs := map[string]struct {
+		data   string
+		tokens []ansi.Token
+	}{
+		"empty": {
+			data:   "",
+			tokens: []ansi.Token{},
+		},
+
+		"text": {
+			data: "asdf",
+			tokens: []ansi.Token{
+				{Kind: ansi.Text, Data: []byte("asdf")},
+			},
+		},
+
+		"sgr": {
+			data: "\033[1;31mas\033[mdf",
+			tokens: []ansi.Token{
+				{Kind: ansi.CSI, Data: []byte("1;31"), Final: 'm'},
+				{Kind: ansi.Text, Data: []byte("as")},
+				{Kind: ansi.CSI, Data: []byte{}, Final: 'm'},
+				{Kind: ansi.Text, Data: []byte("df")},
+			},
+		},
+
+		"private csi": {
+			data: "\033[?25ha",
+			tokens: []ansi.Token{
+				{Kind: ansi.CSI, Data: []byte("?25"), Final: 'h'},
+				{Kind: ansi.Text, Data: []byte("a")},
+			},
+		},
+
+		"malformed csi": {
+			data: "\033[3\na",
+			tokens: []ansi.Token{
+				{Kind: ansi.Text, Data: []byte("\na")},
+			},
+		},
+
+		"osc with bel": {
+			data: "\033]0;title\007a",
+			tokens: []ansi.Token{
+				{Kind: ansi.OSC, Data: []byte("0;title")},
+				{Kind: ansi.Text, Data: []byte("a")},
+			},
+		},
+
+		"osc with st": {
+			data: "\033]0;title\033\\a",
+			tokens: []ansi.Token{
+				{Kind: ansi.OSC, Data: []byte("0;title")},
+				{Kind: ansi.Text, Data: []byte("a")},
+			},
+		},
+
+		"dcs skipped": {
+			data: "\033Pqasdf\033\\a",
+			tokens: []ansi.Token{
+				{Kind: ansi.Text, Data: []byte("a")},
+			},
+		},
+
+		"single character": {
+			data: "\0337a\0338",
+			tokens: []ansi.Token{
+				{Kind: ansi.Escape, Data: []byte{}, Final: '7'},
+				{Kind: ansi.Text, Data: []byte("a")},
+				{Kind: ansi.Escape, Data: []byte{}, Final: '8'},
+			},
+		},
+
+		"charset": {
+			data: "\033(Ba",
+			tokens: []ansi.Token{
+				{Kind: ansi.Escape, Data: []byte("("), Final: 'B'},
+				{Kind: ansi.Text, Data: []byte("a")},
+			},
+		},
+
+		"malformed escape": {
+			data: "\033\na",
+			tokens: []ansi.Token{
+				{Kind: ansi.Text, Data: []byte("\na")},
+			},
+		},
+
+		"unterminated csi": {
+			data: "a\033[31",
+			tokens: []ansi.Token{
+				{Kind: ansi.Text, Data: []byte("a")},
+			},
+		},
+
+		"unterminated osc": {
+			data: "a\033]0;title",
+			tokens: []ansi.Token{
+				{Kind: ansi.Text, Data: []byte("a")},
+			},
+		},
+
+		"lone escape": {
+			data: "a\033",
+			tokens: []ansi.Token{
+				{Kind: ansi.Text, Data: []byte("a")},
+			},
+		},
+	}
+
+	for name, tc := range tcs {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.tokens, ansi.Parse([]byte(tc.data)))
+		})
+	}
+}
+
+func TestClean(t *testing.T) {
+	assert.Equal(t, []byte{}, ansi.Clean([]byte("\033[31m")))
+	assert.Equal(t,
+		[]byte("asdf"),
+		ansi.Clean([]byte("\033[31ma\033]0;title\007s\033[2Kd\033(Bf")),
+	)
+}
+
+func TestHyperlink(t *testing.T) {
+	tcs := map[string]struct {
+		command string
+		url     string
+		id      string
+		ok      bool
+	}{
+		"not a hyperlink": {
+			command: "0;title",
+		},
+
+		"link": {
+			command: "8;;https://achaea.com",
+			url:     "https://achaea.com",
+			ok:      true,
+		},
+
+		"link with id": {
+			command: "8;x=y:id=durak;https://achaea.com",
+			url:     "https://achaea.com",
+			id:      "durak",
+			ok:      true,
+		},
+
+		"end of link": {
+			command: "8;;",
+			ok:      true,
+		},
+	}
+
+	for name, tc := range tcs {
+		t.Run(name, func(t *testing.T) {
+			url, id, ok := ansi.Hyperlink([]byte(tc.command))
+
+			assert.Equal(t, tc.url, url)
+			assert.Equal(t, tc.id, id)
+			assert.Equal(t, tc.ok, ok)
+		})
+	}
+}
@@ -1,12 +1,51 @@
 package pkg
 
+import (
+	"time"
+)
+
 // CharacterVital is a measurement of some vital aspect, like health or mana.
 type CharacterVital struct {
 	Value int
 	Max   int
 }
 
+// CharacterBalance is a resource, like balance or equilibrium, which actions
+// spend and which then takes some time to recover.
+type CharacterBalance struct {
+	Has bool
+
+	// Last is how long the most recent loss lasted.
+	Last time.Duration
+}
+
+// CharacterAffliction is an ailment affecting the character, along with what
+// cures it (e.g. "eat kelp").
+type CharacterAffliction struct {
+	Name string
+	Cure string
+}
+
+// CharacterDetail is a named piece of information about the character, like
+// its class or how much gold it carries.
+type CharacterDetail struct {
+	Name  string
+	Value string
+}
+
 // Character represents the persona being played.
 type Character struct {
-	Vitals map[string]CharacterVital
+	Vitals   map[string]CharacterVital
+	Balances map[string]CharacterBalance
+
+	Afflictions []CharacterAffliction
+	Defences    []string
+
+	// MissingDefences are those the player wants to keep up, but which
+	// the character currently lacks.
+	MissingDefences []string
+
+	// Sheet lists details about the character, in the order they're to
+	// be shown.
+	Sheet []CharacterDetail
 }
@@ -0,0 +1,9 @@
+package pkg
+
+// Communication is a message sent over an in-game channel, like a tell or a
+// city chat.
+type Communication struct {
+	Channel string
+	Talker  string
+	Text    []byte
+}
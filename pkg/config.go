@@ -0,0 +1,179 @@
+package pkg
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/tobiassjosten/nogfx/pkg/simpex"
+)
+
+// Config holds the player's preferences, as read from the configuration file.
+type Config struct {
+	Comm       CommConfig       `json:"comm"`
+	Defences   DefencesConfig   `json:"defences"`
+	Latency    LatencyConfig    `json:"latency"`
+	Layout     *LayoutConfig    `json:"layout,omitempty"`
+	Navigation NavigationConfig `json:"navigation"`
+	Rift       RiftConfig       `json:"rift"`
+	Vitals     VitalsConfig     `json:"vitals"`
+
+	// Dir is the directory the configuration was loaded from, where
+	// other files like maps are kept as well.
+	Dir string `json:"-"`
+
+	// Theme is the name of a built-in theme ("dark", "light",
+	// "high-contrast" or "colorblind") or the path to a theme file.
+	Theme string `json:"theme,omitempty"`
+}
+
+// CommConfig configures how communications are presented.
+type CommConfig struct {
+	// Main lists simpex patterns of channels to also keep in the main
+	// output, in addition to the communications pane.
+	Main []string `json:"main"`
+}
+
+// DefencesConfig configures how defences are tracked.
+type DefencesConfig struct {
+	// Keepup lists defences that should always be up, to be flagged
+	// when they're missing.
+	Keepup []string `json:"keepup"`
+}
+
+// LatencyConfig configures how the connection to the game is monitored.
+type LatencyConfig struct {
+	// Interval is the number of seconds between pings measuring the
+	// round-trip time, or 0 for the default of 30.
+	Interval int `json:"interval"`
+
+	// Silence is the number of seconds without any data from the game
+	// before warning about it, or 0 for the default of 60.
+	Silence int `json:"silence"`
+}
+
+// PingInterval is how long to wait between pinging the game.
+func (config LatencyConfig) PingInterval() time.Duration {
+	if config.Interval <= 0 {
+		return 30 * time.Second
+	}
+
+	return time.Duration(config.Interval) * time.Second
+}
+
+// SilenceLimit is how long the game can go without sending data before it's
+// considered a problem.
+func (config LatencyConfig) SilenceLimit() time.Duration {
+	if config.Silence <= 0 {
+		return 60 * time.Second
+	}
+
+	return time.Duration(config.Silence) * time.Second
+}
+
+// LayoutConfig is a node in the tree describing the user interface layout. It
+// either splits its space between its children or shows a single pane.
+type LayoutConfig struct {
+	// Split is "rows" to stack children vertically or "columns" to place
+	// them side by side. Leave it empty for a pane.
+	Split string `json:"split,omitempty"`
+
+	// Gap is the space left between the children of a split.
+	Gap int `json:"gap,omitempty"`
+
+	// Pane is the type of pane to show, or empty for blank space.
+	Pane string `json:"pane,omitempty"`
+
+	// Min and Max bound the size along the parent's split, with Max 0
+	// meaning unbounded. Nodes that can't be given their Min are hidden.
+	Min int `json:"min,omitempty"`
+	Max int `json:"max,omitempty"`
+
+	Children []LayoutConfig `json:"children,omitempty"`
+}
+
+// NavigationConfig configures how paths are found when walking between rooms.
+type NavigationConfig struct {
+	// Weights is the cost of moving in a given direction, relative to the
+	// default of 1, to prefer or shun some kinds of exits.
+	Weights map[string]int `json:"weights"`
+
+	// Avoid lists rooms and environments never to walk through.
+	Avoid NavigationAvoidConfig `json:"avoid"`
+}
+
+// NavigationAvoidConfig lists what to avoid when walking between rooms.
+type NavigationAvoidConfig struct {
+	Rooms        []int    `json:"rooms"`
+	Environments []string `json:"environments"`
+}
+
+// RiftConfig configures how the rift, where herbs and minerals are stored, is
+// tracked.
+type RiftConfig struct {
+	// Thresholds are amounts of items, by name, below which to warn
+	// about running low.
+	Thresholds map[string]int `json:"thresholds"`
+}
+
+// VitalsConfig configures how vitals are presented.
+type VitalsConfig struct {
+	// Summary adds a line to the output summarizing how vitals changed,
+	// like "-432 hp, +120 mp".
+	Summary bool `json:"summary"`
+}
+
+// NewConfig creates a new Config with default values.
+func NewConfig() *Config {
+	return &Config{
+		Comm: CommConfig{
+			Main: []string{"*"},
+		},
+	}
+}
+
+// LoadConfig reads the configuration file at the given path, on top of the
+// default values. A missing file is not an error but yields the defaults.
+func LoadConfig(path string) (*Config, error) {
+	config := NewConfig()
+	config.Dir = filepath.Dir(path)
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return config, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("failed reading config: %w", err)
+	}
+
+	if err := json.Unmarshal(data, config); err != nil {
+		return nil, fmt.Errorf("failed parsing config: %w", err)
+	}
+
+	return config, nil
+}
+
+// Path builds a path to a file kept alongside the configuration, or returns an
+// empty string if there's no such location.
+func (config *Config) Path(elem ...string) string {
+	if config.Dir == "" {
+		return ""
+	}
+
+	return filepath.Join(append([]string{config.Dir}, elem...)...)
+}
+
+// CommInMain determines whether the given channel should also be shown in the
+// main output.
+func (config *Config) CommInMain(channel string) bool {
+	for _, pattern := range config.Comm.Main {
+		if simpex.Match([]byte(pattern), []byte(channel)) != nil {
+			return true
+		}
+	}
+
+	return false
+}
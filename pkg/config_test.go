@@ -0,0 +1,197 @@
+package pkg_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/tobiassjosten/nogfx/pkg"
+
+	"github.com/icza/gox/gox"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadConfig(t *testing.T) {
+	tcs := map[string]struct {
+		data   *string
+		config *pkg.Config
+		err    string
+	}{
+		"missing file": {
+			config: pkg.NewConfig(),
+		},
+
+		"empty object": {
+			data:   gox.NewString(`{}`),
+			config: pkg.NewConfig(),
+		},
+
+		"comm main channels": {
+			data: gox.NewString(`{"comm":{"main":["ct","tell *"]}}`),
+			config: &pkg.Config{
+				Comm: pkg.CommConfig{
+					Main: []string{"ct", "tell *"},
+				},
+			},
+		},
+
+		"keepup defences": {
+			data: gox.NewString(`{"defences":{"keepup":["deafness"]}}`),
+			config: &pkg.Config{
+				Comm: pkg.CommConfig{
+					Main: []string{"*"},
+				},
+				Defences: pkg.DefencesConfig{
+					Keepup: []string{"deafness"},
+				},
+			},
+		},
+
+		"rift thresholds": {
+			data: gox.NewString(`{"rift":{"thresholds":{"kelp":20}}}`),
+			config: &pkg.Config{
+				Comm: pkg.CommConfig{
+					Main: []string{"*"},
+				},
+				Rift: pkg.RiftConfig{
+					Thresholds: map[string]int{"kelp": 20},
+				},
+			},
+		},
+
+		"latency": {
+			data: gox.NewString(`{"latency":{"interval":10,"silence":120}}`),
+			config: &pkg.Config{
+				Comm: pkg.CommConfig{
+					Main: []string{"*"},
+				},
+				Latency: pkg.LatencyConfig{
+					Interval: 10,
+					Silence:  120,
+				},
+			},
+		},
+
+		"vitals summary": {
+			data: gox.NewString(`{"vitals":{"summary":true}}`),
+			config: &pkg.Config{
+				Comm: pkg.CommConfig{
+					Main: []string{"*"},
+				},
+				Vitals: pkg.VitalsConfig{
+					Summary: true,
+				},
+			},
+		},
+
+		"navigation": {
+			data: gox.NewString(`{"navigation":{"weights":{"u":3},"avoid":{"rooms":[12],"environments":["Sewer"]}}}`),
+			config: &pkg.Config{
+				Comm: pkg.CommConfig{
+					Main: []string{"*"},
+				},
+				Navigation: pkg.NavigationConfig{
+					Weights: map[string]int{"u": 3},
+					Avoid: pkg.NavigationAvoidConfig{
+						Rooms:        []int{12},
+						Environments: []string{"Sewer"},
+					},
+				},
+			},
+		},
+
+		"theme": {
+			data: gox.NewString(`{"theme":"colorblind"}`),
+			config: &pkg.Config{
+				Comm: pkg.CommConfig{
+					Main: []string{"*"},
+				},
+				Theme: "colorblind",
+			},
+		},
+
+		"invalid json": {
+			data: gox.NewString(`{`),
+			err:  "failed parsing config: unexpected end of JSON input",
+		},
+	}
+
+	for name, tc := range tcs {
+		t.Run(name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "config.json")
+
+			if tc.data != nil {
+				err := os.WriteFile(path, []byte(*tc.data), 0600)
+				require.Nil(t, err)
+			}
+
+			config, err := pkg.LoadConfig(path)
+
+			if tc.err != "" {
+				require.NotNil(t, err)
+				assert.Equal(t, tc.err, err.Error())
+
+				return
+			}
+
+			require.Nil(t, err)
+
+			tc.config.Dir = filepath.Dir(path)
+			assert.Equal(t, tc.config, config)
+		})
+	}
+}
+
+func TestConfigCommInMain(t *testing.T) {
+	tcs := map[string]struct {
+		main    []string
+		channel string
+		inMain  bool
+	}{
+		"default": {
+			main:    pkg.NewConfig().Comm.Main,
+			channel: "ct",
+			inMain:  true,
+		},
+
+		"none": {
+			main:    []string{},
+			channel: "ct",
+			inMain:  false,
+		},
+
+		"exact": {
+			main:    []string{"ct"},
+			channel: "ct",
+			inMain:  true,
+		},
+
+		"exact mismatch": {
+			main:    []string{"ct"},
+			channel: "clt1",
+			inMain:  false,
+		},
+
+		"wildcard": {
+			main:    []string{"ct", "tell *"},
+			channel: "tell Durak",
+			inMain:  true,
+		},
+	}
+
+	for name, tc := range tcs {
+		t.Run(name, func(t *testing.T) {
+			config := &pkg.Config{Comm: pkg.CommConfig{Main: tc.main}}
+			assert.Equal(t, tc.inMain, config.CommInMain(tc.channel))
+		})
+	}
+}
+
+func TestConfigPath(t *testing.T) {
+	config := pkg.NewConfig()
+	assert.Equal(t, "", config.Path("maps", "achaea.json"))
+
+	config.Dir = "/home/durak/nogfx"
+	assert.Equal(t, "/home/durak/nogfx/maps/achaea.json", config.Path("maps", "achaea.json"))
+}
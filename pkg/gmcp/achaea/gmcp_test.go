@@ -34,7 +34,7 @@ func TestParse(t *testing.T) {
 
 		"non-existent": {
 			data: "Non.Existant",
-			err:  "unknown message 'Non.Existant'",
+			msg:  &gmcp.RawMessage{MessageID: "Non.Existant"},
 		},
 
 		"invalid JSON": {
@@ -61,3 +61,15 @@ func TestParse(t *testing.T) {
 		})
 	}
 }
+
+func TestParseRegistered(t *testing.T) {
+	gmcp.Register("Char.Vitals", func() gmcp.Message { return &gmcp.RawMessage{} })
+	defer gmcp.Register("Char.Vitals", func() gmcp.Message { return &agmcp.CharVitals{} })
+
+	msg, err := agmcp.Parse([]byte(`Char.Vitals {"hp":"1"}`))
+	assert.Nil(t, err)
+	assert.Equal(t, &gmcp.RawMessage{
+		MessageID: "Char.Vitals",
+		Data:      map[string]any{"hp": "1"},
+	}, msg)
+}
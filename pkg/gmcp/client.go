@@ -0,0 +1,22 @@
+package gmcp
+
+// ClientMap is a GMCP message announcing where to download the official map
+// of the game.
+type ClientMap struct {
+	URL string `json:"url"`
+}
+
+// ID is the prefix before the message's data.
+func (*ClientMap) ID() string {
+	return "Client.Map"
+}
+
+// Marshal converts the message to a string.
+func (msg *ClientMap) Marshal() string {
+	return Marshal(msg)
+}
+
+// Unmarshal populates the message with data.
+func (msg *ClientMap) Unmarshal(data []byte) error {
+	return Unmarshal(data, msg)
+}
@@ -0,0 +1,69 @@
+package gmcp_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/tobiassjosten/nogfx/pkg/gmcp"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClientMessages(t *testing.T) {
+	tcs := map[string]struct {
+		msg         gmcp.Message
+		data        string
+		unmarshaled gmcp.Message
+		marshaled   string
+		err         string
+	}{
+		"Client.Map empty": {
+			msg:         &gmcp.ClientMap{},
+			data:        "Client.Map {}",
+			unmarshaled: &gmcp.ClientMap{},
+			marshaled:   `Client.Map {"url":""}`,
+		},
+
+		"Client.Map hydrated": {
+			msg:  &gmcp.ClientMap{},
+			data: `Client.Map {"url": "https://www.achaea.com/maps/map.xml"}`,
+			unmarshaled: &gmcp.ClientMap{
+				URL: "https://www.achaea.com/maps/map.xml",
+			},
+			marshaled: `Client.Map {"url":"https://www.achaea.com/maps/map.xml"}`,
+		},
+
+		"Client.Map invalid JSON": {
+			msg:  &gmcp.ClientMap{},
+			data: "Client.Map asdf",
+			err:  "invalid character 'a' looking for beginning of value",
+		},
+	}
+
+	for name, tc := range tcs {
+		t.Run(name, func(t *testing.T) {
+			err := tc.msg.Unmarshal([]byte(tc.data))
+
+			if tc.err != "" {
+				require.NotNil(t, err)
+				assert.Equal(t, tc.err, err.Error())
+				return
+			}
+			require.Nil(t, err)
+
+			assert.Equal(t, tc.unmarshaled, tc.msg, "unmarshaling hydrates message")
+
+			marshaled := tc.msg.Marshal()
+			data := strings.TrimSpace(strings.TrimPrefix(marshaled, tc.msg.ID()))
+			tcdata := strings.TrimSpace(strings.TrimPrefix(tc.marshaled, tc.msg.ID()))
+
+			assert.NotEqual(t, marshaled, data, "marshaled data has ID prefix")
+			assert.NotEqual(t, tc.marshaled, tcdata, "marshaled data has ID prefix")
+
+			assert.JSONEq(t, tcdata, data, "marshaling maintains data integrity")
+
+			require.Equal(t, tc.unmarshaled, tc.msg, "marshaling doesn't mutate")
+		})
+	}
+}
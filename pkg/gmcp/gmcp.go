@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"strconv"
 	"strings"
+	"sync"
 
 	"github.com/tobiassjosten/nogfx/pkg/telnet"
 )
@@ -43,6 +44,8 @@ var messages = map[string]func() Message{
 	(&CharSkillsInfo{}).ID():   func() Message { return &CharSkillsInfo{} },
 	(&CharSkillsList{}).ID():   func() Message { return &CharSkillsList{} },
 
+	(&ClientMap{}).ID(): func() Message { return &ClientMap{} },
+
 	(&CommChannelEnable{}).ID():  func() Message { return &CommChannelEnable{} },
 	(&CommChannelList{}).ID():    func() Message { return &CommChannelList{} },
 	(&CommChannelPlayers{}).ID(): func() Message { return &CommChannelPlayers{} },
@@ -62,15 +65,48 @@ var messages = map[string]func() Message{
 	(&RoomRemovePlayer{}).ID(): func() Message { return &RoomRemovePlayer{} },
 }
 
-// Parse converts a byte slice into a GMCP message.
+// Guards registered messages, which can be added to at runtime.
+var registeredMutex sync.RWMutex
+
+// registered are types of messages added at runtime, which take precedence
+// over the built-in ones, including those of game-specific packages.
+var registered = map[string]func() Message{}
+
+// Register adds a type of message to parse, replacing any built-in or
+// previously registered type for the same ID. Game-specific packages parse
+// registered types before their own.
+func Register(id string, constructor func() Message) {
+	registeredMutex.Lock()
+	defer registeredMutex.Unlock()
+
+	registered[id] = constructor
+}
+
+// Registered finds the type of message registered for an ID, if any.
+func Registered(id string) (func() Message, bool) {
+	registeredMutex.RLock()
+	defer registeredMutex.RUnlock()
+
+	constructor, ok := registered[id]
+
+	return constructor, ok
+}
+
+// Parse converts a byte slice into a GMCP message, with those of unknown types
+// becoming a RawMessage. Only data that can't be unmarshaled is an error.
 func Parse(data []byte) (Message, error) {
-	parts := strings.SplitN(string(data), " ", 2)
+	id := strings.SplitN(string(data), " ", 2)[0]
+
+	constructor, ok := Registered(id)
+	if !ok {
+		constructor, ok = messages[id]
+	}
 
-	if _, ok := messages[parts[0]]; !ok {
-		return nil, fmt.Errorf("unknown message '%s'", parts[0])
+	if !ok {
+		constructor = func() Message { return &RawMessage{} }
 	}
 
-	msg := messages[parts[0]]()
+	msg := constructor()
 
 	if err := msg.Unmarshal(data); err != nil {
 		return nil, fmt.Errorf("couldn't unmarshal %T: %w", msg, err)
@@ -123,6 +123,11 @@ func TestParse(t *testing.T) {
 			msgs:  []gmcp.Message{&gmcp.CharSkillsList{}},
 		},
 
+		"Client.Map": {
+			datas: []string{"Client.Map {}"},
+			msgs:  []gmcp.Message{&gmcp.ClientMap{}},
+		},
+
 		"Comm.Channel.Enable": {
 			datas: []string{`Comm.Channel.Enable ""`},
 			msgs:  []gmcp.Message{&gmcp.CommChannelEnable{}},
@@ -199,8 +204,16 @@ func TestParse(t *testing.T) {
 		},
 
 		"non-existent": {
-			datas: []string{"Non.Existant"},
-			errs:  []string{"unknown message 'Non.Existant'"},
+			datas: []string{`Non.Existant {"a":1}`},
+			msgs: []gmcp.Message{&gmcp.RawMessage{
+				MessageID: "Non.Existant",
+				Data:      map[string]any{"a": float64(1)},
+			}},
+		},
+
+		"non-existent invalid JSON": {
+			datas: []string{"Non.Existant asdf"},
+			errs:  []string{"couldn't unmarshal *gmcp.RawMessage: invalid character 'a' looking for beginning of value"},
 		},
 
 		"invalid JSON": {
@@ -16,15 +16,21 @@ var messages = map[string]func() gmcp.Message{
 	(&IRETargetInfo{}).ID(): func() gmcp.Message { return &IRETargetInfo{} },
 }
 
-// Parse converts a byte slice into a GMCP message.
+// Parse converts a byte slice into a GMCP message, with registered types taking
+// precedence over these and others falling back on the more general ones.
 func Parse(data []byte) (gmcp.Message, error) {
-	parts := strings.SplitN(string(data), " ", 2)
+	id := strings.SplitN(string(data), " ", 2)[0]
 
-	if _, ok := messages[parts[0]]; !ok {
+	constructor, ok := gmcp.Registered(id)
+	if !ok {
+		constructor, ok = messages[id]
+	}
+
+	if !ok {
 		return gmcp.Parse(data)
 	}
 
-	msg := messages[parts[0]]()
+	msg := constructor()
 
 	if err := msg.Unmarshal(data); err != nil {
 		return nil, fmt.Errorf("couldn't unmarshal %T: %w", msg, err)
@@ -49,7 +49,7 @@ func TestParse(t *testing.T) {
 
 		"non-existent": {
 			data: "Non.Existant",
-			err:  "unknown message 'Non.Existant'",
+			msg:  &gmcp.RawMessage{MessageID: "Non.Existant"},
 		},
 
 		"invalid JSON": {
@@ -76,3 +76,12 @@ func TestParse(t *testing.T) {
 		})
 	}
 }
+
+func TestParseRegistered(t *testing.T) {
+	gmcp.Register("IRE.Rift.Request", func() gmcp.Message { return &gmcp.RawMessage{} })
+	defer gmcp.Register("IRE.Rift.Request", func() gmcp.Message { return &ironrealms.IRERiftRequest{} })
+
+	msg, err := ironrealms.Parse([]byte("IRE.Rift.Request"))
+	assert.Nil(t, err)
+	assert.Equal(t, &gmcp.RawMessage{MessageID: "IRE.Rift.Request"}, msg)
+}
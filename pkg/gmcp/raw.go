@@ -0,0 +1,47 @@
+package gmcp
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+)
+
+// RawMessage is a GMCP message of a type we don't know, keeping its ID and its
+// data decoded as generic JSON values.
+type RawMessage struct {
+	MessageID string
+
+	// Data is the decoded payload, like map[string]any for an object, or
+	// nil if there's none.
+	Data any
+}
+
+// ID is the prefix before the message's data.
+func (msg *RawMessage) ID() string {
+	return msg.MessageID
+}
+
+// Marshal converts the message to a string.
+func (msg *RawMessage) Marshal() string {
+	if msg.Data == nil {
+		return msg.MessageID
+	}
+
+	data, _ := json.Marshal(msg.Data)
+
+	return fmt.Sprintf("%s %s", msg.MessageID, string(data))
+}
+
+// Unmarshal populates the message with data.
+func (msg *RawMessage) Unmarshal(data []byte) error {
+	id, payload, _ := bytes.Cut(data, []byte{' '})
+
+	msg.MessageID = string(id)
+	msg.Data = nil
+
+	if payload = bytes.TrimSpace(payload); len(payload) == 0 {
+		return nil
+	}
+
+	return json.Unmarshal(payload, &msg.Data)
+}
@@ -0,0 +1,98 @@
+package gmcp_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/tobiassjosten/nogfx/pkg/gmcp"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRawMessage(t *testing.T) {
+	tcs := map[string]struct {
+		msg         gmcp.Message
+		data        string
+		unmarshaled gmcp.Message
+		marshaled   string
+		err         string
+	}{
+		"empty": {
+			msg:         &gmcp.RawMessage{},
+			data:        "Some.Thing",
+			unmarshaled: &gmcp.RawMessage{MessageID: "Some.Thing"},
+			marshaled:   "Some.Thing",
+		},
+
+		"hydrated": {
+			msg: &gmcp.RawMessage{},
+			data: makeGMCP("Some.Thing", map[string]any{
+				"a": 1,
+				"b": []string{"c"},
+			}),
+			unmarshaled: &gmcp.RawMessage{
+				MessageID: "Some.Thing",
+				Data: map[string]any{
+					"a": float64(1),
+					"b": []any{"c"},
+				},
+			},
+			marshaled: makeGMCP("Some.Thing", map[string]any{
+				"a": 1,
+				"b": []string{"c"},
+			}),
+		},
+
+		"invalid JSON": {
+			msg:  &gmcp.RawMessage{},
+			data: "Some.Thing asdf",
+			err:  "invalid character 'a' looking for beginning of value",
+		},
+	}
+
+	for name, tc := range tcs {
+		t.Run(name, func(t *testing.T) {
+			err := tc.msg.Unmarshal([]byte(tc.data))
+
+			if tc.err != "" {
+				require.NotNil(t, err)
+				assert.Equal(t, tc.err, err.Error())
+				return
+			} else if err != nil {
+				require.Equal(t, "", err.Error())
+			}
+
+			require.Equal(t, tc.unmarshaled, tc.msg, "unmarshaling hydrates message")
+
+			marshaled := tc.msg.Marshal()
+			data := strings.TrimSpace(strings.TrimPrefix(marshaled, tc.msg.ID()))
+			tcdata := strings.TrimSpace(strings.TrimPrefix(tc.marshaled, tc.msg.ID()))
+
+			if tcdata == "" {
+				assert.Equal(t, tc.marshaled, marshaled)
+				return
+			}
+
+			assert.JSONEq(t, tcdata, data, "marshaling maintains data integrity")
+		})
+	}
+}
+
+type registeredMessage struct {
+	gmcp.RawMessage
+}
+
+func TestRegister(t *testing.T) {
+	gmcp.Register("Registered.Message", func() gmcp.Message {
+		return &registeredMessage{}
+	})
+
+	msg, err := gmcp.Parse([]byte(`Registered.Message {"a":1}`))
+	require.Nil(t, err)
+
+	assert.Equal(t, &registeredMessage{gmcp.RawMessage{
+		MessageID: "Registered.Message",
+		Data:      map[string]any{"a": float64(1)},
+	}}, msg)
+}
@@ -2,16 +2,19 @@ package pkg
 
 import (
 	"bytes"
+
+	"github.com/tobiassjosten/nogfx/pkg/ansi"
 )
 
 // IOKind signifies the direction of the IO, whether it's player input or
-// server output.
+// server output, or GMCP messages from the server.
 type IOKind string
 
 // These are the known directions of IO.
 const (
 	Input  = IOKind("input")
 	Output = IOKind("output")
+	GMCP   = IOKind("gmcp")
 )
 
 // Inoutput collects one paragraph of output lines and one list of input
@@ -73,43 +76,9 @@ func (inout Inoutput) OmitOutput(i int) Inoutput {
 // Text is a byte slice, with some utility methods, used for Input and Output.
 type Text []byte
 
-// Clean removes ANSI colors from the Text.
+// Clean removes ANSI colors, and other escape sequences, from the Text.
 func (txt Text) Clean() []byte {
-	clean := []byte{}
-
-	var (
-		escape   byte = 27
-		escaped       = false
-		escaping      = false
-	)
-
-	for _, b := range txt {
-		if b == escape {
-			escaped = true
-			continue
-		}
-
-		if escaped {
-			escaped = false
-
-			if b == '[' {
-				escaping = true
-				continue
-			}
-		}
-
-		if escaping {
-			if b == 'm' {
-				escaping = false
-			}
-
-			continue
-		}
-
-		clean = append(clean, b)
-	}
-
-	return clean
+	return ansi.Clean(txt)
 }
 
 // Replace changes the visible parts of a Text while retaining ANSI colors.
@@ -127,14 +127,24 @@ func TestClean(t *testing.T) {
 			out: []byte("asdf"),
 		},
 
-		"invalid start": {
+		"charset escape": {
 			in:  []byte("\033(35masdf"),
-			out: []byte("(35masdf"),
+			out: []byte("5masdf"),
+		},
+
+		"cursor movement": {
+			in:  []byte("\033[35asdf\033[2K"),
+			out: []byte("sdf"),
+		},
+
+		"window title": {
+			in:  []byte("\033]0;Achaea\007asdf"),
+			out: []byte("asdf"),
 		},
 
 		"unterminated": {
-			in:  []byte("\033[35asdf"),
-			out: []byte(""),
+			in:  []byte("asdf\033[35"),
+			out: []byte("asdf"),
 		},
 	}
 
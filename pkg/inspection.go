@@ -0,0 +1,33 @@
+package pkg
+
+import (
+	"bytes"
+	"time"
+)
+
+// GMCPEntry is a GMCP message sent to or received from the game, as recorded
+// for inspection.
+type GMCPEntry struct {
+	Time     time.Time
+	Outgoing bool
+
+	ID string
+
+	// Data is the message's JSON payload, or nil if it has none.
+	Data []byte
+}
+
+// NewGMCPEntry records an unwrapped GMCP message, splitting its ID from its
+// payload.
+func NewGMCPEntry(message []byte, outgoing bool) GMCPEntry {
+	entry := GMCPEntry{Time: time.Now(), Outgoing: outgoing}
+
+	id, data, _ := bytes.Cut(message, []byte{' '})
+	entry.ID = string(id)
+
+	if data = bytes.TrimSpace(data); len(data) > 0 {
+		entry.Data = append([]byte{}, data...)
+	}
+
+	return entry
+}
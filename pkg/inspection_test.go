@@ -0,0 +1,44 @@
+package pkg_test
+
+import (
+	"testing"
+
+	"github.com/tobiassjosten/nogfx/pkg"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewGMCPEntry(t *testing.T) {
+	tcs := map[string]struct {
+		message []byte
+		id      string
+		data    []byte
+	}{
+		"with data": {
+			message: []byte(`Char.Name {"name":"Durak"}`),
+			id:      "Char.Name",
+			data:    []byte(`{"name":"Durak"}`),
+		},
+
+		"without data": {
+			message: []byte("Char.Items.Inv"),
+			id:      "Char.Items.Inv",
+		},
+
+		"blank data": {
+			message: []byte("Core.Ping "),
+			id:      "Core.Ping",
+		},
+	}
+
+	for name, tc := range tcs {
+		t.Run(name, func(t *testing.T) {
+			entry := pkg.NewGMCPEntry(tc.message, true)
+
+			assert.Equal(t, tc.id, entry.ID)
+			assert.Equal(t, tc.data, entry.Data)
+			assert.True(t, entry.Outgoing)
+			assert.False(t, entry.Time.IsZero())
+		})
+	}
+}
@@ -0,0 +1,38 @@
+package pkg
+
+import "time"
+
+// Latency describes the health of the connection to the game.
+type Latency struct {
+	// History holds the most recent round-trip times, oldest first.
+	History []time.Duration
+
+	// Silence is how long the game has gone without sending any data, set
+	// only once it's been quiet for longer than is configured.
+	Silence time.Duration
+}
+
+// Current is the most recently measured round-trip time, or zero if there's
+// none yet.
+func (latency Latency) Current() time.Duration {
+	if len(latency.History) == 0 {
+		return 0
+	}
+
+	return latency.History[len(latency.History)-1]
+}
+
+// Average is the mean of the measured round-trip times, or zero if there are
+// none yet.
+func (latency Latency) Average() time.Duration {
+	if len(latency.History) == 0 {
+		return 0
+	}
+
+	var sum time.Duration
+	for _, rtt := range latency.History {
+		sum += rtt
+	}
+
+	return sum / time.Duration(len(latency.History))
+}
@@ -0,0 +1,290 @@
+package navigation
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+
+	"github.com/tobiassjosten/nogfx/pkg/gmcp"
+
+	"github.com/icza/gox/gox"
+)
+
+// Map is the collection of areas and rooms known about a game, with rooms
+// linked to each other through their exits.
+type Map struct {
+	Areas map[int]*Area
+	Rooms map[int]*Room
+
+	modified bool
+}
+
+// NewMap creates a new, empty Map.
+func NewMap() *Map {
+	return &Map{
+		Areas: map[int]*Area{},
+		Rooms: map[int]*Room{},
+	}
+}
+
+// LoadMap reads the map file at the given path. A missing file is not an error
+// but yields an empty map.
+func LoadMap(path string) (*Map, error) {
+	m := NewMap()
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return m, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("failed reading map: %w", err)
+	}
+
+	if err := m.UnmarshalJSON(data); err != nil {
+		return nil, fmt.Errorf("failed parsing map: %w", err)
+	}
+
+	m.modified = false
+
+	return m, nil
+}
+
+// Save writes the map to a file at the given path, replacing it atomically.
+func (m *Map) Save(path string) error {
+	data, err := json.Marshal(m)
+	if err != nil {
+		return fmt.Errorf("failed encoding map: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), os.ModePerm); err != nil {
+		return fmt.Errorf("failed creating map directory: %w", err)
+	}
+
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0600); err != nil {
+		return fmt.Errorf("failed writing map: %w", err)
+	}
+
+	if err := os.Rename(tmp, path); err != nil {
+		return fmt.Errorf("failed writing map: %w", err)
+	}
+
+	m.modified = false
+
+	return nil
+}
+
+// Modified reports whether the map has changed since it was loaded or last
+// saved.
+func (m *Map) Modified() bool {
+	return m.modified
+}
+
+// Area finds the area with the given ID, creating it if it doesn't exist.
+func (m *Map) Area(id int) *Area {
+	area, ok := m.Areas[id]
+	if !ok {
+		area = &Area{ID: id}
+		m.Areas[id] = area
+		m.modified = true
+	}
+
+	return area
+}
+
+// Room finds the room with the given ID, creating it if it doesn't exist. New
+// rooms are unknown until we have information about them.
+func (m *Map) Room(id int) *Room {
+	room, ok := m.Rooms[id]
+	if !ok {
+		room = &Room{ID: id}
+		m.Rooms[id] = room
+	}
+
+	return room
+}
+
+// RoomFromGMCP merges a GMCP Room.Info message into the map and returns the
+// room it describes.
+func (m *Map) RoomFromGMCP(msg *gmcp.RoomInfo) *Room {
+	room := m.Room(msg.Number)
+	before := room.file()
+
+	room.Name = msg.Name
+	room.Known = true
+
+	if msg.Environment != "" {
+		room.Environment = msg.Environment
+	}
+
+	if msg.Details != nil {
+		room.Details = msg.Details
+	}
+
+	if msg.AreaNumber != 0 {
+		room.Area = m.Area(msg.AreaNumber)
+		if msg.AreaName != "" && room.Area.Name != msg.AreaName {
+			room.Area.Name = msg.AreaName
+			m.modified = true
+		}
+
+		room.X = gox.NewInt(msg.X)
+		room.Y = gox.NewInt(msg.Y)
+	}
+
+	if msg.Exits != nil {
+		room.Exits = map[string]*Room{}
+
+		for direction, number := range msg.Exits {
+			room.Exits[direction] = m.Room(number)
+		}
+	}
+
+	// GMCP has no levels, so we go by the rooms above or below this one.
+	if room.Z == nil {
+		room.Z = room.inferLevel()
+	}
+
+	if !before.Known || !reflect.DeepEqual(before, room.file()) {
+		m.modified = true
+	}
+
+	return room
+}
+
+// mapFile is the format of persisted maps, where rooms refer to areas and
+// each other by their IDs.
+type mapFile struct {
+	Areas []areaFile `json:"areas"`
+	Rooms []roomFile `json:"rooms"`
+}
+
+type areaFile struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+type roomFile struct {
+	ID          int            `json:"id"`
+	Name        string         `json:"name"`
+	Area        int            `json:"area,omitempty"`
+	X           *int           `json:"x,omitempty"`
+	Y           *int           `json:"y,omitempty"`
+	Z           *int           `json:"z,omitempty"`
+	Environment string         `json:"environment,omitempty"`
+	Details     []string       `json:"details,omitempty"`
+	Notes       []string       `json:"notes,omitempty"`
+	Tags        []string       `json:"tags,omitempty"`
+	Exits       map[string]int `json:"exits,omitempty"`
+	Known       bool           `json:"-"`
+}
+
+func (room *Room) file() roomFile {
+	file := roomFile{
+		ID:          room.ID,
+		Name:        room.Name,
+		Environment: room.Environment,
+		Notes:       room.Notes,
+		Tags:        room.Tags,
+		Known:       room.Known,
+	}
+
+	if len(room.Details) > 0 {
+		file.Details = room.Details
+	}
+
+	if room.Area != nil {
+		file.Area = room.Area.ID
+	}
+
+	if room.X != nil && room.Y != nil {
+		file.X, file.Y = gox.NewInt(*room.X), gox.NewInt(*room.Y)
+	}
+
+	if room.Z != nil {
+		file.Z = gox.NewInt(*room.Z)
+	}
+
+	if len(room.Exits) > 0 {
+		file.Exits = map[string]int{}
+		for direction, adjacent := range room.Exits {
+			file.Exits[direction] = adjacent.ID
+		}
+	}
+
+	return file
+}
+
+// MarshalJSON encodes the map, leaving out rooms we know nothing about.
+func (m *Map) MarshalJSON() ([]byte, error) {
+	file := mapFile{Areas: []areaFile{}, Rooms: []roomFile{}}
+
+	for _, area := range m.Areas {
+		file.Areas = append(file.Areas, areaFile{ID: area.ID, Name: area.Name})
+	}
+
+	sort.Slice(file.Areas, func(i, j int) bool {
+		return file.Areas[i].ID < file.Areas[j].ID
+	})
+
+	for _, room := range m.Rooms {
+		if room.Known {
+			file.Rooms = append(file.Rooms, room.file())
+		}
+	}
+
+	sort.Slice(file.Rooms, func(i, j int) bool {
+		return file.Rooms[i].ID < file.Rooms[j].ID
+	})
+
+	return json.Marshal(file)
+}
+
+// UnmarshalJSON decodes a map, merging it into the current one.
+func (m *Map) UnmarshalJSON(data []byte) error {
+	file := mapFile{}
+	if err := json.Unmarshal(data, &file); err != nil {
+		return err
+	}
+
+	if m.Areas == nil {
+		m.Areas = map[int]*Area{}
+	}
+
+	if m.Rooms == nil {
+		m.Rooms = map[int]*Room{}
+	}
+
+	for _, af := range file.Areas {
+		m.Area(af.ID).Name = af.Name
+	}
+
+	for _, rf := range file.Rooms {
+		room := m.Room(rf.ID)
+		room.Name = rf.Name
+		room.X, room.Y, room.Z = rf.X, rf.Y, rf.Z
+		room.Environment = rf.Environment
+		room.Details = rf.Details
+		room.Notes, room.Tags = rf.Notes, rf.Tags
+		room.Known = true
+
+		room.Area = nil
+		if rf.Area != 0 {
+			room.Area = m.Area(rf.Area)
+		}
+
+		room.Exits = nil
+		if len(rf.Exits) > 0 {
+			room.Exits = map[string]*Room{}
+			for direction, id := range rf.Exits {
+				room.Exits[direction] = m.Room(id)
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,186 @@
+package navigation_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/tobiassjosten/nogfx/pkg/gmcp"
+	"github.com/tobiassjosten/nogfx/pkg/navigation"
+
+	"github.com/icza/gox/gox"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRoomFromGMCP(t *testing.T) {
+	nmap := navigation.NewMap()
+
+	room := nmap.RoomFromGMCP(&gmcp.RoomInfo{
+		Number:      1,
+		Name:        "A room",
+		AreaNumber:  12,
+		AreaName:    "Hashan",
+		Environment: "Urban",
+		X:           1,
+		Y:           2,
+		Building:    3,
+		Exits:       map[string]int{"n": 2},
+	})
+
+	assert.True(t, room.Known)
+	assert.Equal(t, "Hashan", room.Area.Name)
+	assert.Equal(t, 2, *room.Y)
+	assert.Nil(t, room.Z)
+	assert.False(t, room.Exits["n"].Known)
+	assert.True(t, nmap.Modified())
+
+	adjacent := nmap.RoomFromGMCP(&gmcp.RoomInfo{
+		Number:     2,
+		Name:       "Another room",
+		AreaNumber: 12,
+		Exits:      map[string]int{"s": 1, "e": 3},
+	})
+
+	assert.Same(t, room.Exits["n"], adjacent)
+	assert.Same(t, room, adjacent.Exits["s"])
+	assert.Same(t, room.Area, adjacent.Area)
+	assert.Equal(t, "Hashan", adjacent.Area.Name)
+
+	// New information is merged into already known rooms.
+	merged := nmap.RoomFromGMCP(&gmcp.RoomInfo{
+		Number:     1,
+		Name:       "A renamed room",
+		AreaNumber: 12,
+		X:          1,
+		Y:          2,
+		Exits:      map[string]int{"n": 2, "w": 4},
+	})
+
+	assert.Same(t, room, merged)
+	assert.Equal(t, "A renamed room", room.Name)
+	assert.Equal(t, "Urban", room.Environment)
+	assert.True(t, room.HasExit("w"))
+
+	// Details are replaced whenever they're given.
+	nmap.RoomFromGMCP(&gmcp.RoomInfo{Number: 1, Details: []string{"bank"}})
+	assert.True(t, room.HasDetail("bank"))
+	assert.False(t, room.HasDetail("shop"))
+}
+
+func TestRoomFromGMCPLevel(t *testing.T) {
+	nmap := navigation.NewMap()
+	require.Nil(t, nmap.ImportXMLFile("testdata/map.xml"))
+
+	// Levels from the official map are kept, since GMCP has none.
+	tunnel := nmap.RoomFromGMCP(&gmcp.RoomInfo{
+		Number:     4,
+		Name:       "A dank tunnel",
+		AreaNumber: 33,
+		X:          -2,
+		Y:          5,
+		Building:   7,
+		Exits:      map[string]int{"u": 1},
+	})
+	assert.Equal(t, -1, *tunnel.Z)
+
+	// Rooms without levels are placed relative to those above or below.
+	cellar := nmap.RoomFromGMCP(&gmcp.RoomInfo{
+		Number: 7,
+		Name:   "A cellar",
+		Exits:  map[string]int{"u": 4},
+	})
+	require.NotNil(t, cellar.Z)
+	assert.Equal(t, -2, *cellar.Z)
+
+	attic := nmap.RoomFromGMCP(&gmcp.RoomInfo{
+		Number: 8,
+		Name:   "An attic",
+		Exits:  map[string]int{"d": 1},
+	})
+	require.NotNil(t, attic.Z)
+	assert.Equal(t, 1, *attic.Z)
+
+	// Without anything to go by, the level stays unknown.
+	shed := nmap.RoomFromGMCP(&gmcp.RoomInfo{
+		Number: 9,
+		Name:   "A shed",
+		Exits:  map[string]int{"e": 1},
+	})
+	assert.Nil(t, shed.Z)
+}
+
+func TestMapModified(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "map.json")
+
+	nmap := navigation.NewMap()
+	assert.False(t, nmap.Modified())
+
+	info := &gmcp.RoomInfo{Number: 1, Name: "A room", Exits: map[string]int{}}
+
+	nmap.RoomFromGMCP(info)
+	assert.True(t, nmap.Modified())
+
+	require.Nil(t, nmap.Save(path))
+	assert.False(t, nmap.Modified())
+
+	nmap.RoomFromGMCP(info)
+	assert.False(t, nmap.Modified())
+
+	nmap.RoomFromGMCP(&gmcp.RoomInfo{Number: 1, Name: "A renamed room"})
+	assert.True(t, nmap.Modified())
+}
+
+func TestSaveLoadMap(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "maps", "achaea.json")
+
+	nmap := navigation.NewMap()
+	nmap.RoomFromGMCP(&gmcp.RoomInfo{
+		Number:      1,
+		Name:        "A room",
+		AreaNumber:  12,
+		AreaName:    "Hashan",
+		Environment: "Urban",
+		X:           3,
+		Y:           -4,
+		Details:     []string{"shop"},
+		Exits:       map[string]int{"n": 2},
+	})
+	nmap.Rooms[1].Z = gox.NewInt(1)
+
+	require.Nil(t, nmap.Save(path))
+
+	loaded, err := navigation.LoadMap(path)
+	require.Nil(t, err)
+	assert.False(t, loaded.Modified())
+
+	room := loaded.Rooms[1]
+	require.NotNil(t, room)
+	assert.True(t, room.Known)
+	assert.Equal(t, "A room", room.Name)
+	assert.Equal(t, "Urban", room.Environment)
+	assert.Equal(t, 3, *room.X)
+	assert.Equal(t, -4, *room.Y)
+	assert.Equal(t, 1, *room.Z)
+	assert.True(t, room.HasDetail("shop"))
+	assert.Equal(t, "Hashan", room.Area.Name)
+	assert.Same(t, loaded.Areas[12], room.Area)
+
+	// Adjacent rooms we haven't visited are recreated from exits.
+	require.Contains(t, room.Exits, "n")
+	assert.Same(t, loaded.Rooms[2], room.Exits["n"])
+	assert.False(t, room.Exits["n"].Known)
+}
+
+func TestLoadMap(t *testing.T) {
+	nmap, err := navigation.LoadMap(filepath.Join(t.TempDir(), "nonexistent.json"))
+	require.Nil(t, err)
+	assert.Empty(t, nmap.Rooms)
+
+	path := filepath.Join(t.TempDir(), "map.json")
+	require.Nil(t, os.WriteFile(path, []byte(`{`), 0600))
+
+	_, err = navigation.LoadMap(path)
+	require.NotNil(t, err)
+	assert.Equal(t, "failed parsing map: unexpected end of JSON input", err.Error())
+}
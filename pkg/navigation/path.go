@@ -0,0 +1,174 @@
+package navigation
+
+import (
+	"container/heap"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Step is one move along a path, through an exit to the adjacent room.
+type Step struct {
+	Direction string
+	Room      *Room
+}
+
+// PathOptions tunes how paths are found.
+type PathOptions struct {
+	// Weights is the cost of moving through exits in a given direction,
+	// with all others costing 1. A weight of 0 or less is also 1.
+	Weights map[string]int
+
+	// AvoidRooms lists IDs of rooms never to pass through.
+	AvoidRooms []int
+
+	// AvoidEnvironments lists environments never to pass through.
+	AvoidEnvironments []string
+}
+
+func (opts PathOptions) weight(direction string) int {
+	if weight := opts.Weights[direction]; weight > 0 {
+		return weight
+	}
+
+	return 1
+}
+
+func (opts PathOptions) avoids(room *Room) bool {
+	for _, id := range opts.AvoidRooms {
+		if room.ID == id {
+			return true
+		}
+	}
+
+	for _, environment := range opts.AvoidEnvironments {
+		if room.Environment != "" && strings.EqualFold(room.Environment, environment) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Path finds the cheapest way from a room to the nearest one satisfying the
+// goal. Rooms to avoid are only entered if they're the goal itself. It reports
+// false if there's no such way.
+func Path(from *Room, goal func(*Room) bool, opts PathOptions) ([]Step, bool) {
+	type visit struct {
+		from *Room
+		step Step
+	}
+
+	costs := map[*Room]int{from: 0}
+	visits := map[*Room]visit{}
+
+	queue := &pathQueue{{room: from}}
+
+	for queue.Len() > 0 {
+		current := heap.Pop(queue).(pathItem)
+		if current.cost > costs[current.room] {
+			continue
+		}
+
+		if goal(current.room) {
+			steps := []Step{}
+
+			for room := current.room; room != from; room = visits[room].from {
+				steps = append([]Step{visits[room].step}, steps...)
+			}
+
+			return steps, true
+		}
+
+		directions := make([]string, 0, len(current.room.Exits))
+		for direction := range current.room.Exits {
+			directions = append(directions, direction)
+		}
+
+		sort.Strings(directions)
+
+		for _, direction := range directions {
+			adjacent := current.room.Exits[direction]
+
+			if opts.avoids(adjacent) && !goal(adjacent) {
+				continue
+			}
+
+			cost := current.cost + opts.weight(direction)
+			if known, ok := costs[adjacent]; ok && known <= cost {
+				continue
+			}
+
+			costs[adjacent] = cost
+			visits[adjacent] = visit{current.room, Step{direction, adjacent}}
+			heap.Push(queue, pathItem{adjacent, cost})
+		}
+	}
+
+	return nil, false
+}
+
+// Destination creates a goal for finding paths, from a room ID, a room name, a
+// tag or an area name. Names and tags are matched regardless of case. It
+// reports false if no room in the map matches.
+func (m *Map) Destination(query string) (func(*Room) bool, bool) {
+	query = strings.TrimSpace(query)
+
+	if id, err := strconv.Atoi(query); err == nil {
+		_, ok := m.Rooms[id]
+
+		return func(room *Room) bool { return room.ID == id }, ok
+	}
+
+	goals := []func(*Room) bool{
+		func(room *Room) bool {
+			return room.Known && strings.EqualFold(room.Name, query)
+		},
+		func(room *Room) bool {
+			return room.HasTag(query)
+		},
+		func(room *Room) bool {
+			return room.Area != nil && strings.EqualFold(room.Area.Name, query)
+		},
+	}
+
+	for _, goal := range goals {
+		for _, room := range m.Rooms {
+			if goal(room) {
+				return goal, true
+			}
+		}
+	}
+
+	return nil, false
+}
+
+type pathItem struct {
+	room *Room
+	cost int
+}
+
+// pathQueue is a priority queue of rooms to visit, cheapest first.
+type pathQueue []pathItem
+
+func (pq pathQueue) Len() int { return len(pq) }
+
+func (pq pathQueue) Less(i, j int) bool {
+	if pq[i].cost == pq[j].cost {
+		return pq[i].room.ID < pq[j].room.ID
+	}
+
+	return pq[i].cost < pq[j].cost
+}
+
+func (pq pathQueue) Swap(i, j int) { pq[i], pq[j] = pq[j], pq[i] }
+
+func (pq *pathQueue) Push(x any) { *pq = append(*pq, x.(pathItem)) }
+
+func (pq *pathQueue) Pop() any {
+	old := *pq
+	item := old[len(old)-1]
+	*pq = old[:len(old)-1]
+
+	return item
+}
@@ -0,0 +1,183 @@
+package navigation_test
+
+import (
+	"testing"
+
+	"github.com/tobiassjosten/nogfx/pkg/gmcp"
+	"github.com/tobiassjosten/nogfx/pkg/navigation"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testMap builds a map with two ways from the square (1) to the temple (3):
+// north along the boulevard (2) or the long way around through the garden (4)
+// and the sewer (5).
+func testMap() *navigation.Map {
+	nmap := navigation.NewMap()
+
+	for _, info := range []*gmcp.RoomInfo{
+		{Number: 1, Name: "Square", Exits: map[string]int{"n": 2, "e": 4}},
+		{Number: 2, Name: "Boulevard", Exits: map[string]int{"n": 3, "s": 1}},
+		{Number: 3, Name: "Temple", Exits: map[string]int{"s": 2, "e": 5}},
+		{Number: 4, Name: "Garden", Environment: "Garden", Exits: map[string]int{"w": 1, "u": 5}},
+		{Number: 5, Name: "Sewer", Environment: "Sewer", Exits: map[string]int{"d": 4, "w": 3}},
+		{Number: 6, Name: "Island", AreaNumber: 9, AreaName: "Isle"},
+	} {
+		nmap.RoomFromGMCP(info)
+	}
+
+	return nmap
+}
+
+func directions(steps []navigation.Step) (dirs []string) {
+	for _, step := range steps {
+		dirs = append(dirs, step.Direction)
+	}
+
+	return dirs
+}
+
+func TestPath(t *testing.T) {
+	nmap := testMap()
+
+	to := func(id int) func(*navigation.Room) bool {
+		return func(room *navigation.Room) bool { return room.ID == id }
+	}
+
+	tcs := map[string]struct {
+		from  int
+		goal  func(*navigation.Room) bool
+		opts  navigation.PathOptions
+		path  []string
+		found bool
+	}{
+		"shortest": {
+			from:  1,
+			goal:  to(3),
+			path:  []string{"n", "n"},
+			found: true,
+		},
+
+		"weighted": {
+			from:  1,
+			goal:  to(3),
+			opts:  navigation.PathOptions{Weights: map[string]int{"n": 5}},
+			path:  []string{"e", "u", "w"},
+			found: true,
+		},
+
+		"avoid room": {
+			from:  1,
+			goal:  to(3),
+			opts:  navigation.PathOptions{AvoidRooms: []int{2}},
+			path:  []string{"e", "u", "w"},
+			found: true,
+		},
+
+		"avoid environment": {
+			from: 1,
+			goal: to(3),
+			opts: navigation.PathOptions{
+				AvoidRooms:        []int{2},
+				AvoidEnvironments: []string{"sewer"},
+			},
+		},
+
+		"avoided goal": {
+			from:  1,
+			goal:  to(2),
+			opts:  navigation.PathOptions{AvoidRooms: []int{2}},
+			path:  []string{"n"},
+			found: true,
+		},
+
+		"unreachable": {
+			from: 1,
+			goal: to(6),
+		},
+
+		"already there": {
+			from:  1,
+			goal:  to(1),
+			path:  nil,
+			found: true,
+		},
+	}
+
+	for name, tc := range tcs {
+		t.Run(name, func(t *testing.T) {
+			steps, found := navigation.Path(nmap.Rooms[tc.from], tc.goal, tc.opts)
+
+			assert.Equal(t, tc.found, found)
+			assert.Equal(t, tc.path, directions(steps))
+
+			if len(steps) > 0 {
+				assert.True(t, tc.goal(steps[len(steps)-1].Room))
+			}
+		})
+	}
+}
+
+func TestDestination(t *testing.T) {
+	nmap := testMap()
+	nmap.Tag(nmap.Rooms[5], "Gate")
+
+	tcs := map[string]struct {
+		query string
+		rooms []int
+		found bool
+	}{
+		"room id": {
+			query: "3",
+			rooms: []int{3},
+			found: true,
+		},
+
+		"room name": {
+			query: "temple",
+			rooms: []int{3},
+			found: true,
+		},
+
+		"tag": {
+			query: "gate",
+			rooms: []int{5},
+			found: true,
+		},
+
+		"area name": {
+			query: "ISLE",
+			rooms: []int{6},
+			found: true,
+		},
+
+		"unknown id": {
+			query: "123",
+		},
+
+		"unknown name": {
+			query: "Nowhere",
+		},
+	}
+
+	for name, tc := range tcs {
+		t.Run(name, func(t *testing.T) {
+			goal, found := nmap.Destination(tc.query)
+			require.Equal(t, tc.found, found)
+
+			if !found {
+				return
+			}
+
+			rooms := []int{}
+			for id, room := range nmap.Rooms {
+				if goal(room) {
+					rooms = append(rooms, id)
+				}
+			}
+
+			assert.Equal(t, tc.rooms, rooms)
+		})
+	}
+}
@@ -3,24 +3,9 @@ package navigation
 import (
 	"strings"
 
-	"github.com/tobiassjosten/nogfx/pkg/gmcp"
-
 	"github.com/icza/gox/gox"
 )
 
-// @todo Encapsulate in a struct, so as to avoid polluting global space.
-var (
-	areas = map[int]*Area{}
-	rooms = map[int]*Room{}
-)
-
-// Reset clears the area and room caches. A temporary fix until we implement
-// proper encapsulation.
-func Reset() {
-	areas = map[int]*Area{}
-	rooms = map[int]*Room{}
-}
-
 // Area is a region covering a number of rooms.
 type Area struct {
 	ID   int
@@ -35,56 +20,26 @@ type Room struct {
 	X *int
 	Y *int
 
-	HasPlayer bool
-
-	Known bool
-
-	Area *Area
+	// Z is the level of the room, with higher values being further up.
+	Z *int
 
-	Exits map[string]*Room
-}
-
-// RoomFromGMCP creates a Room from a GMCP Room.Info message.
-func RoomFromGMCP(msg *gmcp.RoomInfo) *Room {
-	room, ok := rooms[msg.Number]
-	if ok && room.Known {
-		return room
-	} else if !ok {
-		room = &Room{ID: msg.Number}
-		rooms[msg.Number] = room
-	}
+	Environment string
 
-	room.Name = msg.Name
-	room.X = gox.NewInt(msg.X)
-	room.Y = gox.NewInt(msg.Y)
-	room.Known = true
+	// Details are features of the room, like "shop" or "bank".
+	Details []string
 
-	if msg.Exits != nil {
-		room.Exits = map[string]*Room{}
+	// Notes and Tags are what players want to remember about the room,
+	// like "harvest here" and "gate".
+	Notes []string
+	Tags  []string
 
-		for direction, number := range msg.Exits {
-			adjacent, ok := rooms[number]
-			if !ok {
-				adjacent = &Room{ID: number}
-				rooms[number] = adjacent
-			}
-
-			room.Exits[direction] = adjacent
-		}
-	}
+	HasPlayer bool
 
-	area, ok := areas[msg.AreaNumber]
-	if !ok && msg.AreaNumber != 0 {
-		area = &Area{
-			ID:   msg.AreaNumber,
-			Name: msg.AreaName,
-		}
-		areas[msg.AreaNumber] = area
-	}
+	Known bool
 
-	room.Area = area
+	Area *Area
 
-	return room
+	Exits map[string]*Room
 }
 
 // HasExit determines whether the room has a specific exit or not. It supports
@@ -100,6 +55,17 @@ func (room *Room) HasExit(directions string) (exists bool) {
 	return true
 }
 
+// HasDetail determines whether the room has a specific feature, like "shop".
+func (room *Room) HasDetail(wanted string) bool {
+	for _, detail := range room.Details {
+		if detail == wanted {
+			return true
+		}
+	}
+
+	return false
+}
+
 // HasAnyExits determine whether the room has ANY of the specific exit sequences.
 func (room *Room) HasAnyExits(directionses ...string) bool {
 	for _, directions := range directionses {
@@ -172,3 +138,19 @@ func (room *Room) Displacement(direction string) (x int, y int) {
 
 	return 0, 0
 }
+
+// inferLevel determines a room's level from an adjacent room directly above or
+// below it, whose level is known.
+func (room *Room) inferLevel() *int {
+	for _, vertical := range []struct {
+		direction string
+		offset    int
+	}{{"u", -1}, {"d", 1}} {
+		adjacent, ok := room.Exits[vertical.direction]
+		if ok && adjacent.Z != nil {
+			return gox.NewInt(*adjacent.Z + vertical.offset)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,119 @@
+package navigation
+
+import (
+	"sort"
+	"strings"
+)
+
+// Nearby is a room and how many moves away it is.
+type Nearby struct {
+	Room *Room
+
+	// Distance is the number of moves to the room, or -1 if it can't be
+	// reached.
+	Distance int
+}
+
+// HasTag determines whether the room has been tagged with a specific tag,
+// regardless of case.
+func (room *Room) HasTag(wanted string) bool {
+	for _, tag := range room.Tags {
+		if strings.EqualFold(tag, wanted) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// AddNote attaches a note to the room.
+func (m *Map) AddNote(room *Room, note string) {
+	room.Notes = append(room.Notes, note)
+	m.modified = true
+}
+
+// ClearNotes removes all notes from the room.
+func (m *Map) ClearNotes(room *Room) {
+	if len(room.Notes) == 0 {
+		return
+	}
+
+	room.Notes = nil
+	m.modified = true
+}
+
+// Tag tags the room, unless it's already been. Tags are kept in lowercase.
+func (m *Map) Tag(room *Room, tag string) {
+	if room.HasTag(tag) {
+		return
+	}
+
+	room.Tags = append(room.Tags, strings.ToLower(tag))
+	sort.Strings(room.Tags)
+	m.modified = true
+}
+
+// Untag removes a tag from the room and reports whether it was there.
+func (m *Map) Untag(room *Room, tag string) bool {
+	for i, t := range room.Tags {
+		if strings.EqualFold(t, tag) {
+			room.Tags = append(room.Tags[:i], room.Tags[i+1:]...)
+			m.modified = true
+
+			return true
+		}
+	}
+
+	return false
+}
+
+// Tagged lists rooms with the given tag, nearest first as counted in moves
+// from the given room. Rooms that can't be reached come last, in order of
+// their IDs.
+func (m *Map) Tagged(tag string, from *Room) []Nearby {
+	distances := map[*Room]int{}
+
+	if from != nil {
+		distances[from] = 0
+
+		for queue := []*Room{from}; len(queue) > 0; queue = queue[1:] {
+			for _, adjacent := range queue[0].Exits {
+				if _, ok := distances[adjacent]; !ok {
+					distances[adjacent] = distances[queue[0]] + 1
+					queue = append(queue, adjacent)
+				}
+			}
+		}
+	}
+
+	rooms := []Nearby{}
+
+	for _, room := range m.Rooms {
+		if !room.HasTag(tag) {
+			continue
+		}
+
+		distance, ok := distances[room]
+		if !ok {
+			distance = -1
+		}
+
+		rooms = append(rooms, Nearby{room, distance})
+	}
+
+	sort.Slice(rooms, func(i, j int) bool {
+		a, b := rooms[i], rooms[j]
+
+		if a.Distance != b.Distance {
+			if a.Distance < 0 || b.Distance < 0 {
+				return b.Distance < 0
+			}
+
+			return a.Distance < b.Distance
+		}
+
+		return a.Room.ID < b.Room.ID
+	})
+
+	return rooms
+}
@@ -0,0 +1,96 @@
+package navigation_test
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/tobiassjosten/nogfx/pkg/navigation"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNotesAndTags(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "map.json")
+
+	nmap := testMap()
+	require.Nil(t, nmap.Save(path))
+
+	room := nmap.Rooms[1]
+
+	nmap.AddNote(room, "harvest here")
+	assert.True(t, nmap.Modified())
+	assert.Equal(t, []string{"harvest here"}, room.Notes)
+
+	nmap.Tag(room, "Gate")
+	nmap.Tag(room, "gate")
+	nmap.Tag(room, "city")
+	assert.Equal(t, []string{"city", "gate"}, room.Tags)
+	assert.True(t, room.HasTag("GATE"))
+
+	require.Nil(t, nmap.Save(path))
+
+	loaded, err := navigation.LoadMap(path)
+	require.Nil(t, err)
+	assert.Equal(t, []string{"harvest here"}, loaded.Rooms[1].Notes)
+	assert.Equal(t, []string{"city", "gate"}, loaded.Rooms[1].Tags)
+
+	assert.True(t, nmap.Untag(room, "city"))
+	assert.False(t, nmap.Untag(room, "city"))
+	assert.Equal(t, []string{"gate"}, room.Tags)
+	assert.True(t, nmap.Modified())
+
+	require.Nil(t, nmap.Save(path))
+
+	nmap.ClearNotes(room)
+	nmap.ClearNotes(room)
+	assert.Empty(t, room.Notes)
+	assert.True(t, nmap.Modified())
+}
+
+func TestTagged(t *testing.T) {
+	nmap := testMap()
+
+	for _, id := range []int{1, 3, 5, 6} {
+		nmap.Tag(nmap.Rooms[id], "harvest")
+	}
+
+	tcs := map[string]struct {
+		from   *navigation.Room
+		tag    string
+		nearby []navigation.Nearby
+	}{
+		"nearest first": {
+			from: nmap.Rooms[4],
+			tag:  "harvest",
+			nearby: []navigation.Nearby{
+				{Room: nmap.Rooms[1], Distance: 1},
+				{Room: nmap.Rooms[5], Distance: 1},
+				{Room: nmap.Rooms[3], Distance: 2},
+				{Room: nmap.Rooms[6], Distance: -1},
+			},
+		},
+
+		"no current room": {
+			tag: "HARVEST",
+			nearby: []navigation.Nearby{
+				{Room: nmap.Rooms[1], Distance: -1},
+				{Room: nmap.Rooms[3], Distance: -1},
+				{Room: nmap.Rooms[5], Distance: -1},
+				{Room: nmap.Rooms[6], Distance: -1},
+			},
+		},
+
+		"unknown tag": {
+			from:   nmap.Rooms[1],
+			tag:    "gate",
+			nearby: []navigation.Nearby{},
+		},
+	}
+
+	for name, tc := range tcs {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.nearby, nmap.Tagged(tc.tag, tc.from))
+		})
+	}
+}
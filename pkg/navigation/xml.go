@@ -0,0 +1,217 @@
+package navigation
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/icza/gox/gox"
+)
+
+// Exit directions in map files and their abbreviations, as used in GMCP.
+var xmlDirections = map[string]string{
+	"north":     "n",
+	"northeast": "ne",
+	"east":      "e",
+	"southeast": "se",
+	"south":     "s",
+	"southwest": "sw",
+	"west":      "w",
+	"northwest": "nw",
+	"up":        "u",
+	"down":      "d",
+	"in":        "in",
+	"out":       "out",
+}
+
+// xmlMap is the map file format of Iron Realms games, as announced through
+// the Client.Map GMCP message.
+type xmlMap struct {
+	Areas []struct {
+		ID   int    `xml:"id,attr"`
+		Name string `xml:"name,attr"`
+	} `xml:"areas>area"`
+
+	Rooms []struct {
+		ID          int    `xml:"id,attr"`
+		Area        int    `xml:"area,attr"`
+		Title       string `xml:"title,attr"`
+		Environment int    `xml:"environment,attr"`
+
+		Coord *struct {
+			X int `xml:"x,attr"`
+			Y int `xml:"y,attr"`
+			Z int `xml:"z,attr"`
+		} `xml:"coord"`
+
+		Exits []struct {
+			Direction string `xml:"direction,attr"`
+			Target    int    `xml:"target,attr"`
+		} `xml:"exit"`
+	} `xml:"rooms>room"`
+
+	Environments []struct {
+		ID   int    `xml:"id,attr"`
+		Name string `xml:"name,attr"`
+	} `xml:"environments>environment"`
+}
+
+// XMLFile is a parsed map file of the Iron Realms format, ready to be merged
+// into a map. Parsing is the slow part of an import, so it can be done apart
+// from the map itself.
+type XMLFile struct {
+	file xmlMap
+}
+
+// ParseXML parses a map file of the Iron Realms format.
+func ParseXML(r io.Reader) (*XMLFile, error) {
+	decoder := xml.NewDecoder(r)
+	decoder.CharsetReader = charsetReader
+
+	file := xmlMap{}
+	if err := decoder.Decode(&file); err != nil {
+		return nil, fmt.Errorf("failed parsing map xml: %w", err)
+	}
+
+	return &XMLFile{file: file}, nil
+}
+
+// ParseXMLFile parses the map file at the given path.
+func ParseXMLFile(path string) (*XMLFile, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed opening map xml: %w", err)
+	}
+	defer file.Close()
+
+	return ParseXML(file)
+}
+
+// ImportXML merges a map file of the Iron Realms format into the map. Rooms
+// in the file replace what we know about them already.
+func (m *Map) ImportXML(r io.Reader) error {
+	xfile, err := ParseXML(r)
+	if err != nil {
+		return err
+	}
+
+	m.MergeXML(xfile)
+
+	return nil
+}
+
+// MergeXML merges a parsed map file into the map. Rooms in the file replace
+// what we know about them already.
+func (m *Map) MergeXML(xfile *XMLFile) {
+	file := xfile.file
+
+	environments := map[int]string{}
+	for _, env := range file.Environments {
+		environments[env.ID] = env.Name
+	}
+
+	for _, xarea := range file.Areas {
+		m.Area(xarea.ID).Name = xarea.Name
+	}
+
+	for _, xroom := range file.Rooms {
+		room := m.Room(xroom.ID)
+		room.Name = xroom.Title
+		room.Environment = environments[xroom.Environment]
+		room.Known = true
+
+		room.Area = nil
+		if xroom.Area != 0 {
+			room.Area = m.Area(xroom.Area)
+		}
+
+		room.X, room.Y, room.Z = nil, nil, nil
+		if xroom.Coord != nil {
+			room.X = gox.NewInt(xroom.Coord.X)
+			room.Y = gox.NewInt(xroom.Coord.Y)
+			room.Z = gox.NewInt(xroom.Coord.Z)
+		}
+
+		room.Exits = map[string]*Room{}
+		for _, exit := range xroom.Exits {
+			direction, ok := xmlDirections[exit.Direction]
+			if !ok {
+				direction = exit.Direction
+			}
+
+			room.Exits[direction] = m.Room(exit.Target)
+		}
+	}
+
+	m.modified = true
+}
+
+// ImportXMLFile merges the map file at the given path into the map.
+func (m *Map) ImportXMLFile(path string) error {
+	xfile, err := ParseXMLFile(path)
+	if err != nil {
+		return err
+	}
+
+	m.MergeXML(xfile)
+
+	return nil
+}
+
+// DownloadXML fetches a map file from the given URL and stores it at the given
+// path, for importing later.
+func DownloadXML(url, path string) error {
+	client := &http.Client{Timeout: time.Minute}
+
+	resp, err := client.Get(url)
+	if err != nil {
+		return fmt.Errorf("failed downloading map xml: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("failed downloading map xml: %s", resp.Status)
+	}
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("failed downloading map xml: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), os.ModePerm); err != nil {
+		return fmt.Errorf("failed creating map directory: %w", err)
+	}
+
+	if err := os.WriteFile(path, data, 0600); err != nil {
+		return fmt.Errorf("failed writing map xml: %w", err)
+	}
+
+	return nil
+}
+
+// charsetReader lets map files declare themselves as Latin-1, which is common
+// for older games, on top of the UTF-8 supported by default.
+func charsetReader(charset string, input io.Reader) (io.Reader, error) {
+	switch strings.ToLower(charset) {
+	case "iso-8859-1", "latin1", "latin-1":
+		data, err := io.ReadAll(input)
+		if err != nil {
+			return nil, err
+		}
+
+		runes := make([]rune, len(data))
+		for i, b := range data {
+			runes[i] = rune(b)
+		}
+
+		return bytes.NewReader([]byte(string(runes))), nil
+	}
+
+	return nil, fmt.Errorf("unsupported charset '%s'", charset)
+}
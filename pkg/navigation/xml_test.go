@@ -0,0 +1,100 @@
+package navigation_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/tobiassjosten/nogfx/pkg/gmcp"
+	"github.com/tobiassjosten/nogfx/pkg/navigation"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestImportXML(t *testing.T) {
+	nmap := navigation.NewMap()
+
+	// Rooms we already know are overwritten by the official map.
+	nmap.RoomFromGMCP(&gmcp.RoomInfo{
+		Number:      1,
+		Name:        "An outdated name",
+		Environment: "Garden",
+	})
+
+	require.Nil(t, nmap.ImportXMLFile("testdata/map.xml"))
+	assert.True(t, nmap.Modified())
+
+	assert.Equal(t, "Hashan", nmap.Areas[12].Name)
+	assert.Equal(t, "the Moghedu", nmap.Areas[33].Name)
+
+	square := nmap.Rooms[1]
+	require.NotNil(t, square)
+	assert.True(t, square.Known)
+	assert.Equal(t, "Central square of Hashan", square.Name)
+	assert.Equal(t, "Urban", square.Environment)
+	assert.Same(t, nmap.Areas[12], square.Area)
+	assert.Equal(t, 0, *square.X)
+	assert.Equal(t, 0, *square.Y)
+	assert.True(t, square.HasExit("n s"))
+	assert.True(t, square.HasExit("e w"))
+	assert.True(t, square.HasExit("d u"))
+
+	tunnel := nmap.Rooms[4]
+	assert.Equal(t, "Natural underground", tunnel.Environment)
+	assert.Equal(t, -2, *tunnel.X)
+	assert.Equal(t, 5, *tunnel.Y)
+	assert.Equal(t, -1, *tunnel.Z)
+	assert.Same(t, nmap.Areas[33], tunnel.Area)
+
+	// Special exits keep their names and lead to rooms not in the file.
+	require.Contains(t, tunnel.Exits, "worm")
+	assert.False(t, tunnel.Exits["worm"].Known)
+	assert.False(t, nmap.Rooms[3].Exits["in"].Known)
+}
+
+func TestImportXMLErrors(t *testing.T) {
+	nmap := navigation.NewMap()
+
+	err := nmap.ImportXML(strings.NewReader("<map>"))
+	require.NotNil(t, err)
+	assert.Equal(t, "failed parsing map xml: XML syntax error on line 1: unexpected EOF", err.Error())
+
+	err = nmap.ImportXMLFile(filepath.Join(t.TempDir(), "nonexistent.xml"))
+	require.NotNil(t, err)
+	assert.Contains(t, err.Error(), "failed opening map xml")
+
+	err = nmap.ImportXML(strings.NewReader(`<?xml version="1.0" encoding="klingon"?><map/>`))
+	require.NotNil(t, err)
+	assert.Contains(t, err.Error(), "unsupported charset 'klingon'")
+}
+
+func TestDownloadXML(t *testing.T) {
+	fixture, err := os.ReadFile("testdata/map.xml")
+	require.Nil(t, err)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/maps/map.xml" {
+			http.NotFound(w, r)
+			return
+		}
+
+		_, _ = w.Write(fixture)
+	}))
+	defer server.Close()
+
+	path := filepath.Join(t.TempDir(), "maps", "achaea.xml")
+
+	require.Nil(t, navigation.DownloadXML(server.URL+"/maps/map.xml", path))
+
+	data, err := os.ReadFile(path)
+	require.Nil(t, err)
+	assert.Equal(t, fixture, data)
+
+	err = navigation.DownloadXML(server.URL+"/nonexistent.xml", path)
+	require.NotNil(t, err)
+	assert.Equal(t, "failed downloading map xml: 404 Not Found", err.Error())
+}
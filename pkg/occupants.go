@@ -0,0 +1,27 @@
+package pkg
+
+// Occupant is someone or something in the same room as the character.
+type Occupant struct {
+	Name string
+
+	// Marked occupants are of special interest, like denizens among the
+	// target candidates or players sharing a channel with the character.
+	Marked bool
+
+	// Details are notable properties, like an item being a container.
+	Details []string
+}
+
+// Occupants is who and what is in the same room as the character.
+type Occupants struct {
+	Players  []Occupant
+	Denizens []Occupant
+	Items    []Occupant
+}
+
+// Empty determines whether there's no one and nothing in the room.
+func (occupants Occupants) Empty() bool {
+	return len(occupants.Players) == 0 &&
+		len(occupants.Denizens) == 0 &&
+		len(occupants.Items) == 0
+}
@@ -0,0 +1,10 @@
+package pkg
+
+// RiftItem is an amount of something kept in the rift, like a herb or mineral.
+type RiftItem struct {
+	Name   string
+	Amount int
+
+	// Low items have dropped below the amount the player wants to keep.
+	Low bool
+}
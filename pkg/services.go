@@ -34,8 +34,16 @@ type UI interface {
 	MaskInput()
 	UnmaskInput()
 
+	AddCommunication(Communication)
+	AddGMCP(GMCPEntry)
 	SetCharacter(Character)
+	SetLatency(Latency)
+	SetMap(*navigation.Map)
+	SetOccupants(Occupants)
+	SetRift([]RiftItem)
 	SetRoom(*navigation.Room)
+	SetSkills([]SkillGroup)
+	SetStatus(Status)
 	SetTarget(*Target)
 }
 
@@ -44,3 +52,10 @@ type World interface {
 	OnInoutput(Inoutput) Inoutput
 	OnCommand([]byte) Inoutput
 }
+
+// Tasker is a World doing work in the background, like downloading files,
+// whose results are applied from the engine's main loop so as not to race
+// with everything else going on there.
+type Tasker interface {
+	Tasks() <-chan func()
+}
@@ -0,0 +1,38 @@
+package pkg
+
+import (
+	"fmt"
+)
+
+// Skill is an ability within a skill group.
+type Skill struct {
+	Name        string
+	Description string
+
+	// Info is the full explanation of the skill, once it's been looked up.
+	Info string
+}
+
+// SkillGroup is a set of skills, like "Survival", which the character learns
+// and ranks up in.
+type SkillGroup struct {
+	Name string
+	Rank string
+
+	// Progress is the percentage towards the next rank, or -1 if the
+	// game doesn't tell.
+	Progress int
+
+	// Skills are those available to the character, or nil until they've
+	// been listed by the game.
+	Skills []Skill
+}
+
+// RankString describes the rank along with its progress, like "Adept (45%)".
+func (group SkillGroup) RankString() string {
+	if group.Progress < 0 {
+		return group.Rank
+	}
+
+	return fmt.Sprintf("%s (%d%%)", group.Rank, group.Progress)
+}
@@ -0,0 +1,31 @@
+package pkg
+
+import "time"
+
+// These are the states of compression, with MCCP.
+const (
+	MCCPOff      = "off"
+	MCCPDeclined = "declined"
+)
+
+// Status summarizes the session, for an overview of its connection.
+type Status struct {
+	Address string
+
+	// World is the name of the game-specific support in use, if any.
+	World string
+
+	Connected bool
+
+	// Duration is how long the session has lasted, in whole minutes.
+	Duration time.Duration
+
+	GMCP bool
+
+	// MCCP is the state of compression, which is "declined" if the game
+	// offered it, since we don't support it.
+	MCCP string
+
+	// Logfile is the path to where the session is being logged, if it is.
+	Logfile string
+}
@@ -79,6 +79,18 @@ func (tgt *Target) RemovePresent(name string) {
 	}
 }
 
+// IsCandidate determines whether the named entity, e.g. "a ferocious
+// manticore", is a potential target.
+func (tgt *Target) IsCandidate(name string) bool {
+	for _, candidate := range tgt.candidates {
+		if strings.Contains(name, candidate) {
+			return true
+		}
+	}
+
+	return false
+}
+
 // Queue counts valid targets in the same location.
 func (tgt *Target) Queue() int {
 	queue := 0
@@ -175,3 +175,12 @@ func TestTarget(t *testing.T) {
 		})
 	}
 }
+
+func TestTargetIsCandidate(t *testing.T) {
+	tgt := pkg.NewTarget(func(_ string, _ *pkg.Target) {})
+	assert.False(t, tgt.IsCandidate("a ferocious manticore"))
+
+	tgt.SetCandidates([]string{"manticore", "rat"})
+	assert.True(t, tgt.IsCandidate("a ferocious manticore"))
+	assert.False(t, tgt.IsCandidate("a villager"))
+}
@@ -1,6 +1,8 @@
 package pkg
 
 import (
+	"bytes"
+
 	"github.com/tobiassjosten/nogfx/pkg/simpex"
 )
 
@@ -8,6 +10,9 @@ type Match struct {
 	Kind     IOKind
 	Captures [][]byte
 	Index    int
+
+	// Data is the payload of a matched GMCP message, without its ID.
+	Data []byte
 }
 
 type Callback func([]Match, Inoutput) Inoutput
@@ -46,3 +51,21 @@ func (t Trigger) Match(datas [][]byte, inout Inoutput) Inoutput {
 
 	return inout
 }
+
+// MatchGMCP matches the trigger's pattern against the ID of a GMCP message,
+// like "Char.*" for all messages within the Char package, and passes along its
+// payload.
+func (t Trigger) MatchGMCP(message []byte, inout Inoutput) Inoutput {
+	id, data, _ := bytes.Cut(message, []byte{' '})
+
+	captures := simpex.Match(t.Pattern, id)
+	if captures == nil {
+		return inout
+	}
+
+	return t.Callback([]Match{{
+		Kind:     t.Kind,
+		Captures: captures,
+		Data:     data,
+	}}, inout)
+}
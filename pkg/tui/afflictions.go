@@ -0,0 +1,47 @@
+package tui
+
+import (
+	"strings"
+)
+
+// RenderAfflictions renders current afflictions, colored by their cures, and
+// missing defences that should be kept up.
+func (tui *TUI) RenderAfflictions(width, height int) Rows {
+	if rows, ok := tui.getCache(paneAfflictions); ok {
+		return rows
+	}
+
+	row := Row{}
+
+	for _, affliction := range tui.character.Afflictions {
+		cure := strings.SplitN(affliction.Cure, " ", 2)[0]
+
+		style, ok := tui.theme.Lookup("afflictions." + cure)
+		if !ok {
+			style = tui.theme.Style("afflictions")
+		}
+
+		row = row.append(NewRow(min(1, len(row)), NewCell(' '))...)
+		row = row.append(NewRowFromRunes([]rune(affliction.Name), style)...)
+	}
+
+	for _, defence := range tui.character.MissingDefences {
+		row = row.append(NewRow(min(1, len(row)), NewCell(' '))...)
+		row = row.append(NewRowFromRunes(
+			[]rune(defence), tui.theme.Style("afflictions.missing"),
+		)...)
+	}
+
+	rows := Rows{}
+	if len(row) > 0 && width > 0 {
+		rows = row.Wrap(width, NewCell(' '))
+	}
+
+	if len(rows) > height {
+		rows = rows[:height]
+	}
+
+	tui.setCache(paneAfflictions, rows)
+
+	return rows
+}
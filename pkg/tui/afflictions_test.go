@@ -0,0 +1,109 @@
+package tui
+
+import (
+	"testing"
+
+	"github.com/tobiassjosten/nogfx/pkg"
+	"github.com/tobiassjosten/nogfx/pkg/mock"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRenderAfflictions(t *testing.T) {
+	tcs := map[string]struct {
+		character pkg.Character
+		width     int
+		height    int
+		rows      []string
+	}{
+		"nothing": {
+			width:  10,
+			height: 3,
+			rows:   nil,
+		},
+
+		"afflictions": {
+			character: pkg.Character{
+				Afflictions: []pkg.CharacterAffliction{
+					{Name: "asthma", Cure: "eat kelp"},
+					{Name: "anorexia", Cure: "apply epidermal"},
+				},
+			},
+			width:  16,
+			height: 3,
+			rows:   []string{"asthma anorexia "},
+		},
+
+		"wrapped": {
+			character: pkg.Character{
+				Afflictions: []pkg.CharacterAffliction{
+					{Name: "asthma", Cure: "eat kelp"},
+					{Name: "anorexia", Cure: "apply epidermal"},
+				},
+				MissingDefences: []string{"deafness"},
+			},
+			width:  10,
+			height: 3,
+			rows:   []string{"asthma    ", "anorexia  ", "deafness  "},
+		},
+
+		"cramped": {
+			character: pkg.Character{
+				Afflictions: []pkg.CharacterAffliction{
+					{Name: "asthma", Cure: "eat kelp"},
+					{Name: "anorexia", Cure: "apply epidermal"},
+				},
+			},
+			width:  10,
+			height: 1,
+			rows:   []string{"asthma    "},
+		},
+	}
+
+	for name, tc := range tcs {
+		t.Run(name, func(t *testing.T) {
+			ui := NewTUI(&mock.ScreenMock{
+				HideCursorFunc:     func() {},
+				SetCursorStyleFunc: func(_ tcell.CursorStyle) {},
+				SetStyleFunc:       func(_ tcell.Style) {},
+			})
+
+			ui.SetCharacter(tc.character)
+
+			rows := ui.RenderAfflictions(tc.width, tc.height)
+			assert.Equal(t, tc.rows, rows.Strings())
+		})
+	}
+}
+
+func TestRenderAfflictionsStyles(t *testing.T) {
+	ui := NewTUI(&mock.ScreenMock{
+		HideCursorFunc:     func() {},
+		SetCursorStyleFunc: func(_ tcell.CursorStyle) {},
+		SetStyleFunc:       func(_ tcell.Style) {},
+	})
+
+	ui.SetCharacter(pkg.Character{
+		Afflictions: []pkg.CharacterAffliction{
+			{Name: "a", Cure: "eat kelp"},
+			{Name: "b", Cure: "smoke elm"},
+			{Name: "c", Cure: "dance"},
+		},
+		MissingDefences: []string{"d"},
+	})
+
+	rows := ui.RenderAfflictions(7, 1)
+
+	assert.Equal(t, Rows{Row{
+		NewCell('a', (tcell.Style{}).Foreground(tcell.ColorGreen)),
+		NewCell(' '),
+		NewCell('b', (tcell.Style{}).Foreground(tcell.ColorFuchsia)),
+		NewCell(' '),
+		NewCell('c', (tcell.Style{}).Foreground(tcell.ColorSilver)),
+		NewCell(' '),
+		NewCell('d', tcell.StyleDefault.
+			Background(tcell.ColorDarkRed).
+			Foreground(tcell.ColorWhite)),
+	}}, rows)
+}
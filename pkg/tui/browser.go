@@ -0,0 +1,343 @@
+package tui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/tobiassjosten/nogfx/pkg/navigation"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+// Browser is a full-screen map for exploring rooms beyond the minimap, finding
+// them by name and walking to them.
+type Browser struct {
+	room *navigation.Room
+
+	// How far the view is panned from the selected room, in rooms.
+	x, y int
+
+	searching bool
+	query     []rune
+	matches   []*navigation.Room
+	match     int
+}
+
+// Directions to pan the view in, by arrow key.
+var browserPans = map[tcell.Key][2]int{
+	tcell.KeyUp:    {0, -1},
+	tcell.KeyRight: {1, 0},
+	tcell.KeyDown:  {0, 1},
+	tcell.KeyLeft:  {-1, 0},
+}
+
+// Directions to move the selection in, by shifted arrow key.
+var browserDirections = map[tcell.Key]string{
+	tcell.KeyUp:    "n",
+	tcell.KeyRight: "e",
+	tcell.KeyDown:  "s",
+	tcell.KeyLeft:  "w",
+	tcell.KeyPgUp:  "u",
+	tcell.KeyPgDn:  "d",
+}
+
+const browserHelp = "arrows pan, shift+arrows move, pgup/pgdn up/down, tab area, / search, n next, enter walk, esc close"
+
+// ToggleBrowser shows or hides the map browser, starting out from the current
+// room. It reports false if there's no current room to start from.
+func (tui *TUI) ToggleBrowser() bool {
+	tui.clear = true
+	tui.clearCache()
+
+	if tui.browser != nil {
+		tui.browser = nil
+		return true
+	}
+
+	if tui.room == nil {
+		return false
+	}
+
+	tui.browser = &Browser{room: tui.room}
+
+	return true
+}
+
+// focus selects a room and centers the view on it.
+func (browser *Browser) focus(room *navigation.Room) {
+	browser.room = room
+	browser.x, browser.y = 0, 0
+}
+
+// browsable lists all the rooms of the map, those reachable from the current
+// room in order of distance and the rest after them, in order of their IDs.
+func (tui *TUI) browsable() []*navigation.Room {
+	rooms := tui.reachable()
+
+	seen := map[*navigation.Room]struct{}{}
+	for _, room := range rooms {
+		seen[room] = struct{}{}
+	}
+
+	tui.roomsMutex.Lock()
+	defer tui.roomsMutex.Unlock()
+
+	rest := []*navigation.Room{}
+
+	for _, room := range tui.rooms {
+		if _, ok := seen[room]; !ok {
+			rest = append(rest, room)
+		}
+	}
+
+	sort.Slice(rest, func(i, j int) bool {
+		return rest[i].ID < rest[j].ID
+	})
+
+	return append(rooms, rest...)
+}
+
+// reachable lists rooms in order of distance from the current room, as far as
+// their exits take us.
+func (tui *TUI) reachable() []*navigation.Room {
+	if tui.room == nil {
+		return nil
+	}
+
+	rooms := []*navigation.Room{tui.room}
+	seen := map[*navigation.Room]struct{}{tui.room: {}}
+
+	for i := 0; i < len(rooms); i++ {
+		directions := make([]string, 0, len(rooms[i].Exits))
+		for direction := range rooms[i].Exits {
+			directions = append(directions, direction)
+		}
+
+		sort.Strings(directions)
+
+		for _, direction := range directions {
+			adjacent := rooms[i].Exits[direction]
+			if _, ok := seen[adjacent]; ok {
+				continue
+			}
+
+			seen[adjacent] = struct{}{}
+			rooms = append(rooms, adjacent)
+		}
+	}
+
+	return rooms
+}
+
+// handleBrowserEvent reacts to keys while the map browser is shown.
+func (tui *TUI) handleBrowserEvent(ev *tcell.EventKey) bool {
+	browser := tui.browser
+
+	if browser.searching {
+		return tui.handleBrowserSearch(ev)
+	}
+
+	switch ev.Key() {
+	case tcell.KeyEsc:
+		return tui.ToggleBrowser()
+
+	case tcell.KeyEnter:
+		id := browser.room.ID
+		tui.ToggleBrowser()
+		tui.inputs <- []byte(fmt.Sprintf("goto %d", id))
+
+		return true
+
+	case tcell.KeyTab:
+		tui.browseArea(1)
+		return true
+
+	case tcell.KeyBacktab:
+		tui.browseArea(-1)
+		return true
+
+	case tcell.KeyHome:
+		browser.focus(tui.room)
+		return true
+
+	case tcell.KeyRune:
+		switch ev.Rune() {
+		case '/':
+			browser.searching = true
+			browser.query = []rune{}
+
+		case 'n':
+			if len(browser.matches) > 0 {
+				browser.match = (browser.match + 1) % len(browser.matches)
+				browser.focus(browser.matches[browser.match])
+			}
+		}
+
+		return true
+	}
+
+	if pan, ok := browserPans[ev.Key()]; ok && ev.Modifiers()&tcell.ModShift == 0 {
+		browser.x += pan[0]
+		browser.y += pan[1]
+
+		return true
+	}
+
+	if direction, ok := browserDirections[ev.Key()]; ok {
+		if adjacent, ok := browser.room.Exits[direction]; ok {
+			browser.focus(adjacent)
+		}
+	}
+
+	return true
+}
+
+func (tui *TUI) handleBrowserSearch(ev *tcell.EventKey) bool {
+	browser := tui.browser
+
+	switch ev.Key() {
+	case tcell.KeyEsc:
+		browser.searching = false
+
+	case tcell.KeyBackspace, tcell.KeyBackspace2:
+		if len(browser.query) > 0 {
+			browser.query = browser.query[:len(browser.query)-1]
+		}
+
+	case tcell.KeyEnter:
+		browser.searching = false
+		browser.matches = nil
+		browser.match = 0
+
+		query := strings.ToLower(string(browser.query))
+
+		for _, room := range tui.browsable() {
+			if room.Known && strings.Contains(strings.ToLower(room.Name), query) {
+				browser.matches = append(browser.matches, room)
+			}
+		}
+
+		if len(browser.matches) > 0 {
+			browser.focus(browser.matches[0])
+		}
+
+	case tcell.KeyRune:
+		browser.query = append(browser.query, ev.Rune())
+	}
+
+	return true
+}
+
+// browseArea selects the nearest room of the next or previous area, in order
+// of their names.
+func (tui *TUI) browseArea(offset int) {
+	browser := tui.browser
+
+	areas := []*navigation.Area{}
+	nearest := map[*navigation.Area]*navigation.Room{}
+
+	for _, room := range tui.browsable() {
+		if room.Area == nil || !room.Known {
+			continue
+		}
+
+		if _, ok := nearest[room.Area]; !ok {
+			nearest[room.Area] = room
+			areas = append(areas, room.Area)
+		}
+	}
+
+	if len(areas) == 0 {
+		return
+	}
+
+	sort.SliceStable(areas, func(i, j int) bool {
+		return areas[i].Name < areas[j].Name
+	})
+
+	current := 0
+
+	for i, area := range areas {
+		if area == browser.room.Area {
+			current = i
+			break
+		}
+	}
+
+	next := (current + offset + len(areas)) % len(areas)
+	browser.focus(nearest[areas[next]])
+}
+
+// RenderBrowser renders the map browser, with the selected room highlighted
+// in the middle, unless the view is panned away from it.
+func (tui *TUI) RenderBrowser(width, height int) Rows {
+	if tui.browser == nil || width == 0 || height < 3 {
+		return Rows{}
+	}
+
+	browser := tui.browser
+	room := browser.room
+
+	header := fmt.Sprintf("%s (%d)", room.Name, room.ID)
+	if room.Area != nil {
+		header = room.Area.Name + ": " + header
+	}
+
+	footer := browserHelp
+	if browser.searching {
+		footer = "/" + string(browser.query)
+	} else if len(browser.matches) > 0 {
+		footer = fmt.Sprintf(
+			"match %d of %d; %s",
+			browser.match+1, len(browser.matches), browserHelp,
+		)
+	}
+
+	rows := Rows{browserRow(header, width, tui.theme.Style("browser.header"))}
+
+	mmap := renderPannedMap(room, browser.x, browser.y, width, height-2, tui.theme)
+
+	x, y := width/2-4*browser.x, (height-2)/2-2*browser.y
+	if y >= 0 && y < len(mmap) && x > 0 && x+1 < len(mmap[y]) {
+		for xx := x - 1; xx <= x+1; xx++ {
+			mmap[y][xx].Style = tui.theme.Style("browser.selected")
+		}
+	}
+
+	rows = append(rows, mmap...)
+	rows = append(rows, browserRow(footer, width, tui.theme.Style("browser.footer")))
+
+	return rows
+}
+
+// renderPannedMap renders the map from a room on a canvas large enough to
+// reach the panned view, since rooms are only traced as far as they're shown,
+// and crops the view from it. Rooms are four cells apart horizontally and two
+// vertically.
+func renderPannedMap(room *navigation.Room, panx, pany, width, height int, theme *Theme) Rows {
+	padx, pady := 4*abs(panx), 2*abs(pany)
+
+	canvas := RenderMap(room, width+2*padx, height+2*pady, theme)
+	if len(canvas) == 0 {
+		return canvas
+	}
+
+	left, top := padx+4*panx, pady+2*pany
+
+	rows := canvas[top : top+height]
+	for i, row := range rows {
+		rows[i] = row[left : left+width]
+	}
+
+	return rows
+}
+
+func browserRow(text string, width int, style tcell.Style) Row {
+	row := NewRowFromRunes([]rune(text), style)
+	if len(row) > width {
+		return row[:width]
+	}
+
+	return row.append(NewRow(width-len(row), NewCell(' ', style))...)
+}
@@ -0,0 +1,226 @@
+package tui
+
+import (
+	"testing"
+	"time"
+
+	"github.com/tobiassjosten/nogfx/pkg/gmcp"
+	"github.com/tobiassjosten/nogfx/pkg/mock"
+	"github.com/tobiassjosten/nogfx/pkg/navigation"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func browserTUI() *TUI {
+	nmap := navigation.NewMap()
+
+	for _, info := range []*gmcp.RoomInfo{
+		{Number: 1, Name: "Square", AreaNumber: 1, AreaName: "Hashan", Exits: map[string]int{"n": 2, "d": 4}},
+		{Number: 2, Name: "Boulevard", AreaNumber: 1, AreaName: "Hashan", Y: 1, Exits: map[string]int{"s": 1, "e": 3}},
+		{Number: 3, Name: "Gate", AreaNumber: 2, AreaName: "Ashtan", Exits: map[string]int{"w": 2}},
+		{Number: 4, Name: "Sewer", AreaNumber: 3, AreaName: "Sewers", Exits: map[string]int{"u": 1}},
+
+		// An island of rooms, not connected to the others.
+		{Number: 5, Name: "Grove", AreaNumber: 4, AreaName: "Zaphar", Exits: map[string]int{}},
+	} {
+		nmap.RoomFromGMCP(info)
+	}
+
+	ui := NewTUI(&mock.ScreenMock{
+		SetCursorStyleFunc: func(_ tcell.CursorStyle) {},
+		SetStyleFunc:       func(_ tcell.Style) {},
+	})
+
+	ui.SetMap(nmap)
+	ui.room = nmap.Rooms[1]
+
+	return ui
+}
+
+func key(k tcell.Key) *tcell.EventKey {
+	return tcell.NewEventKey(k, 0, 0)
+}
+
+func shifted(k tcell.Key) *tcell.EventKey {
+	return tcell.NewEventKey(k, 0, tcell.ModShift)
+}
+
+func runes(s string) (events []*tcell.EventKey) {
+	for _, r := range s {
+		events = append(events, tcell.NewEventKey(tcell.KeyRune, r, 0))
+	}
+
+	return events
+}
+
+func TestBrowserToggle(t *testing.T) {
+	ui := browserTUI()
+
+	assert.True(t, ui.HandleEvent(key(tcell.KeyF2)))
+	require.NotNil(t, ui.browser)
+	assert.Same(t, ui.room, ui.browser.room)
+
+	// Keys go to the browser instead of the input while it's shown.
+	ui.HandleEvent(runes("x")[0])
+	assert.Empty(t, ui.input.buffer)
+
+	assert.True(t, ui.HandleEvent(key(tcell.KeyEsc)))
+	assert.Nil(t, ui.browser)
+
+	ui.room = nil
+	assert.False(t, ui.HandleEvent(key(tcell.KeyF2)))
+	assert.Nil(t, ui.browser)
+}
+
+func TestBrowserNavigation(t *testing.T) {
+	tcs := map[string]struct {
+		events []*tcell.EventKey
+		room   int
+		pan    [2]int
+	}{
+		"arrow along exit": {
+			events: []*tcell.EventKey{shifted(tcell.KeyUp), shifted(tcell.KeyRight)},
+			room:   3,
+		},
+
+		"arrow without exit": {
+			events: []*tcell.EventKey{shifted(tcell.KeyLeft)},
+			room:   1,
+		},
+
+		"arrows pan": {
+			events: []*tcell.EventKey{key(tcell.KeyLeft), key(tcell.KeyLeft), key(tcell.KeyDown)},
+			room:   1,
+			pan:    [2]int{-2, 1},
+		},
+
+		"moving recenters": {
+			events: []*tcell.EventKey{key(tcell.KeyLeft), shifted(tcell.KeyUp)},
+			room:   2,
+		},
+
+		"level down and up": {
+			events: []*tcell.EventKey{key(tcell.KeyPgDn)},
+			room:   4,
+		},
+
+		"next area": {
+			events: []*tcell.EventKey{key(tcell.KeyTab)},
+			room:   4,
+		},
+
+		"unconnected area": {
+			events: []*tcell.EventKey{key(tcell.KeyTab), key(tcell.KeyTab)},
+			room:   5,
+		},
+
+		"previous area": {
+			events: []*tcell.EventKey{key(tcell.KeyBacktab)},
+			room:   3,
+		},
+
+		"back home": {
+			events: []*tcell.EventKey{shifted(tcell.KeyUp), key(tcell.KeyHome)},
+			room:   1,
+		},
+
+		"search": {
+			events: append(append([]*tcell.EventKey{runes("/")[0]}, runes("gatx")...),
+				key(tcell.KeyBackspace2), runes("e")[0], key(tcell.KeyEnter),
+			),
+			room: 3,
+		},
+
+		"search unconnected": {
+			events: append(append([]*tcell.EventKey{runes("/")[0]}, runes("grove")...),
+				key(tcell.KeyEnter),
+			),
+			room: 5,
+		},
+
+		"search next": {
+			events: append(append([]*tcell.EventKey{runes("/")[0]}, runes("e")...),
+				key(tcell.KeyEnter), runes("n")[0],
+			),
+			room: 4,
+		},
+
+		"search cancelled": {
+			events: append(append([]*tcell.EventKey{runes("/")[0]}, runes("gate")...),
+				key(tcell.KeyEsc),
+			),
+			room: 1,
+		},
+	}
+
+	for name, tc := range tcs {
+		t.Run(name, func(t *testing.T) {
+			ui := browserTUI()
+			ui.ToggleBrowser()
+
+			for _, event := range tc.events {
+				assert.True(t, ui.HandleEvent(event))
+			}
+
+			require.NotNil(t, ui.browser)
+			assert.Equal(t, tc.room, ui.browser.room.ID)
+			assert.Equal(t, tc.pan, [2]int{ui.browser.x, ui.browser.y})
+		})
+	}
+}
+
+func TestBrowserWalk(t *testing.T) {
+	ui := browserTUI()
+	ui.ToggleBrowser()
+	ui.HandleEvent(shifted(tcell.KeyUp))
+
+	go ui.HandleEvent(key(tcell.KeyEnter))
+
+	assert.Equal(t, []byte("goto 2"), <-ui.inputs)
+	assert.Eventually(t, func() bool { return ui.browser == nil }, time.Second, time.Millisecond)
+}
+
+func TestRenderBrowser(t *testing.T) {
+	ui := browserTUI()
+	assert.Equal(t, Rows{}, ui.RenderBrowser(20, 7))
+
+	ui.ToggleBrowser()
+	ui.HandleEvent(shifted(tcell.KeyUp))
+
+	rows := ui.RenderBrowser(20, 7)
+	require.Len(t, rows, 7)
+
+	assert.Equal(t, "Hashan: Boulevard (2", rows[0].String())
+	assert.Equal(t, DefaultTheme().Style("browser.header"), rows[0][0].Style)
+
+	// The selected room is highlighted in the middle of the map.
+	assert.Equal(t, "[ ]", rows[1+2][9:12].String())
+	assert.Equal(t, DefaultTheme().Style("browser.selected"), rows[1+2][10].Style)
+
+	assert.Equal(t, []rune(browserHelp)[:20], []rune(rows[6].String()))
+
+	// Panning shows rooms beyond the unpanned map, with the highlight
+	// following the selected room.
+	ui.HandleEvent(key(tcell.KeyDown))
+
+	rows = ui.RenderBrowser(20, 7)
+	assert.Equal(t, "[ ]", rows[1+0][9:12].String())
+	assert.Equal(t, DefaultTheme().Style("browser.selected"), rows[1+0][10].Style)
+	assert.Equal(t, "[v]", rows[1+2][9:12].String())
+
+	// The view pans over gaps without rooms.
+	ui.HandleEvent(key(tcell.KeyLeft))
+	ui.HandleEvent(key(tcell.KeyLeft))
+
+	rows = ui.RenderBrowser(20, 7)
+	assert.Equal(t, "   ", rows[1+2][9:12].String())
+	assert.Equal(t, "[ ]", rows[1+0][17:20].String())
+	assert.Equal(t, DefaultTheme().Style("browser.selected"), rows[1+0][18].Style)
+
+	ui.HandleEvent(runes("/")[0])
+	ui.HandleEvent(runes("q")[0])
+	rows = ui.RenderBrowser(20, 7)
+	assert.Equal(t, "/q                  ", rows[6].String())
+}
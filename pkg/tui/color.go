@@ -4,79 +4,138 @@ import (
 	"github.com/gdamore/tcell/v2"
 )
 
-var ansiColors = map[int]tcell.Color{
-	0: tcell.ColorBlack,
-	1: tcell.ColorRed,
-	2: tcell.ColorGreen,
-	3: tcell.ColorYellow,
-	4: tcell.ColorBlue,
-	5: tcell.ColorPurple,
-	6: tcell.ColorTeal,
-	7: tcell.ColorWhite,
-	9: tcell.ColorDefault,
+// ApplyANSI modifies a tcell.Style by the given ANSI SGR parameters, as found
+// between the "\033[" and "m" of an escape sequence. Extended colors, like
+// 38;5;n (8-bit) and 38;2;r;g;b (24-bit), span several parameters.
+func ApplyANSI(style tcell.Style, codes ...int) tcell.Style {
+	for i := 0; i < len(codes); i++ {
+		switch code := codes[i]; {
+		case code == 38 || code == 48:
+			color, n, ok := extendedColor(codes[i+1:])
+			i += n
+
+			if !ok {
+				continue
+			}
+
+			if code == 38 {
+				style = style.Foreground(color)
+			} else {
+				style = style.Background(color)
+			}
+
+		default:
+			style = applySGR(style, code)
+		}
+	}
+
+	return style
 }
 
-// ApplyANSI modifies a tcell.Style by the given ANSI code.
-func ApplyANSI(style tcell.Style, ansi int) tcell.Style {
-	switch ansi {
-	case 0:
+// extendedColor reads an 8-bit (5;n) or 24-bit (2;r;g;b) color from the
+// parameters following a 38 or 48. It returns how many of the parameters it
+// spanned and whether they made up a valid color.
+func extendedColor(codes []int) (tcell.Color, int, bool) {
+	size := 0
+
+	switch {
+	case len(codes) == 0:
+		return tcell.ColorDefault, 0, false
+
+	case codes[0] == 5:
+		size = 2
+
+	case codes[0] == 2:
+		size = 4
+
+	default:
+		return tcell.ColorDefault, 1, false
+	}
+
+	if len(codes) < size {
+		return tcell.ColorDefault, len(codes), false
+	}
+
+	for _, c := range codes[1:size] {
+		if c < 0 || c > 255 {
+			return tcell.ColorDefault, size, false
+		}
+	}
+
+	if size == 2 {
+		return tcell.PaletteColor(codes[1]), size, true
+	}
+
+	return tcell.NewRGBColor(
+		int32(codes[1]), int32(codes[2]), int32(codes[3]),
+	), size, true
+}
+
+// applySGR modifies a tcell.Style by a single ANSI SGR parameter.
+func applySGR(style tcell.Style, code int) tcell.Style {
+	switch {
+	case code == 0:
 		return tcell.Style{}
 
-	case 1:
+	case code == 1:
 		return style.Bold(true)
 
-	case 2:
+	case code == 2:
 		return style.Dim(true)
 
-	case 3:
+	case code == 3:
 		return style.Italic(true)
 
-	case 4:
+	case code == 4:
 		return style.Underline(true)
 
-	case 5:
+	case code == 5:
 		return style.Blink(true)
 
-	case 7:
+	case code == 7:
 		return style.Reverse(true)
 
-	case 22:
+	case code == 9:
+		return style.StrikeThrough(true)
+
+	case code == 22:
 		return style.Bold(false).Dim(false)
 
-	case 23:
+	case code == 23:
 		return style.Italic(false)
 
-	case 24:
+	case code == 24:
 		return style.Underline(false)
 
-	case 25:
+	case code == 25:
 		return style.Blink(false)
 
-	case 27:
+	case code == 27:
 		return style.Reverse(false)
 
-	case 30, 31, 32, 33, 34, 35, 36, 37, 39:
-		return style.Foreground(ansiColors[ansi%10])
-
-	case 40, 41, 42, 43, 44, 45, 46, 47, 49:
-		return style.Background(ansiColors[ansi%10])
-
-	case 90, 91, 92, 93, 94, 95, 96, 97, 99:
-		// There might be a better way to represent "high intensity"
-		// colors with tcell but I can't find it.
-		return style.
-			Foreground(ansiColors[ansi%10]).
-			Attributes(tcell.AttrBold)
-
-	case 100, 101, 102, 103, 104, 105, 106, 107, 109:
-		// There might be a better way to represent "high intensity"
-		// colors with tcell but I can't find it.
-		return style.
-			Background(ansiColors[ansi%10]).
-			Attributes(tcell.AttrBold)
-	}
+	case code == 29:
+		return style.StrikeThrough(false)
 
-	// @todo Implement 256-color scale: https://en.wikipedia.org/wiki/ANSI_escape_code#8-bit
+	case code >= 30 && code <= 37:
+		return style.Foreground(tcell.PaletteColor(code - 30))
+
+	case code == 39:
+		return style.Foreground(tcell.ColorDefault)
+
+	case code >= 40 && code <= 47:
+		return style.Background(tcell.PaletteColor(code - 40))
+
+	case code == 49:
+		return style.Background(tcell.ColorDefault)
+
+	// Bright colors are their own colors in the palette, separate from
+	// the bold attribute.
+	case code >= 90 && code <= 97:
+		return style.Foreground(tcell.PaletteColor(code - 90 + 8))
+
+	case code >= 100 && code <= 107:
+		return style.Background(tcell.PaletteColor(code - 100 + 8))
+	}
 
 	return style
 }
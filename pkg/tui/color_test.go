@@ -13,12 +13,12 @@ import (
 func TestApplyANSI(t *testing.T) {
 	tcs := []struct {
 		in   tcell.Style
-		ansi int
+		ansi []int
 		out  tcell.Style
 	}{
 		{
 			in:   tcell.Style{},
-			ansi: 123,
+			ansi: []int{123},
 			out:  tcell.Style{},
 		},
 		{
@@ -26,90 +26,158 @@ func TestApplyANSI(t *testing.T) {
 				Foreground(tcell.ColorRed).
 				Background(tcell.ColorBlue).
 				Attributes(tcell.AttrBold),
-			ansi: 0,
+			ansi: []int{0},
 			out:  tcell.Style{},
 		},
 		{
 			in:   tcell.Style{},
-			ansi: 1,
+			ansi: []int{1},
 			out:  (tcell.Style{}).Attributes(tcell.AttrBold),
 		},
 		{
 			in:   tcell.Style{},
-			ansi: 2,
+			ansi: []int{2},
 			out:  (tcell.Style{}).Attributes(tcell.AttrDim),
 		},
 		{
 			in:   tcell.Style{},
-			ansi: 3,
+			ansi: []int{3},
 			out:  (tcell.Style{}).Attributes(tcell.AttrItalic),
 		},
 		{
 			in:   tcell.Style{},
-			ansi: 4,
+			ansi: []int{4},
 			out:  (tcell.Style{}).Attributes(tcell.AttrUnderline),
 		},
 		{
 			in:   tcell.Style{},
-			ansi: 5,
+			ansi: []int{5},
 			out:  (tcell.Style{}).Attributes(tcell.AttrBlink),
 		},
 		{
 			in:   tcell.Style{},
-			ansi: 7,
+			ansi: []int{7},
 			out:  (tcell.Style{}).Attributes(tcell.AttrReverse),
 		},
 		{
 			in: (tcell.Style{}).Attributes(
 				tcell.AttrBold | tcell.AttrDim | tcell.AttrBlink,
 			),
-			ansi: 22,
+			ansi: []int{22},
 			out:  (tcell.Style{}).Attributes(tcell.AttrBlink),
 		},
 		{
 			in: (tcell.Style{}).
 				Attributes(tcell.AttrItalic | tcell.AttrBlink),
-			ansi: 23,
+			ansi: []int{23},
 			out:  (tcell.Style{}).Attributes(tcell.AttrBlink),
 		},
 		{
 			in: (tcell.Style{}).
 				Attributes(tcell.AttrUnderline | tcell.AttrBlink),
-			ansi: 24,
+			ansi: []int{24},
 			out:  (tcell.Style{}).Attributes(tcell.AttrBlink),
 		},
 		{
 			in: (tcell.Style{}).
 				Attributes(tcell.AttrBlink | tcell.AttrBold),
-			ansi: 25,
+			ansi: []int{25},
 			out:  (tcell.Style{}).Attributes(tcell.AttrBold),
 		},
 		{
 			in: (tcell.Style{}).
 				Attributes(tcell.AttrReverse | tcell.AttrBlink),
-			ansi: 27,
+			ansi: []int{27},
 			out:  (tcell.Style{}).Attributes(tcell.AttrBlink),
 		},
 		{
 			in:   tcell.Style{},
-			ansi: 91,
+			ansi: []int{91},
+			out:  (tcell.Style{}).Foreground(tcell.ColorRed),
+		},
+		{
+			in:   tcell.Style{},
+			ansi: []int{102},
+			out:  (tcell.Style{}).Background(tcell.ColorLime),
+		},
+		{
+			in:   tcell.Style{},
+			ansi: []int{31},
+			out:  (tcell.Style{}).Foreground(tcell.ColorMaroon),
+		},
+		{
+			in:   tcell.Style{},
+			ansi: []int{1, 31},
 			out: (tcell.Style{}).
-				Foreground(tcell.ColorRed).
+				Foreground(tcell.ColorMaroon).
 				Attributes(tcell.AttrBold),
 		},
 		{
-			in:   tcell.Style{},
-			ansi: 102,
+			in:   (tcell.Style{}).Attributes(tcell.AttrBold),
+			ansi: []int{94},
 			out: (tcell.Style{}).
-				Background(tcell.ColorGreen).
+				Foreground(tcell.ColorBlue).
 				Attributes(tcell.AttrBold),
 		},
+		{
+			in:   (tcell.Style{}).Foreground(tcell.ColorRed),
+			ansi: []int{39},
+			out:  (tcell.Style{}).Foreground(tcell.ColorDefault),
+		},
+		{
+			in:   tcell.Style{},
+			ansi: []int{38, 5, 208},
+			out:  (tcell.Style{}).Foreground(tcell.PaletteColor(208)),
+		},
+		{
+			in:   tcell.Style{},
+			ansi: []int{48, 5, 17},
+			out:  (tcell.Style{}).Background(tcell.PaletteColor(17)),
+		},
+		{
+			in:   tcell.Style{},
+			ansi: []int{38, 2, 255, 128, 0},
+			out:  (tcell.Style{}).Foreground(tcell.NewRGBColor(255, 128, 0)),
+		},
+		{
+			in:   tcell.Style{},
+			ansi: []int{48, 2, 1, 2, 3},
+			out:  (tcell.Style{}).Background(tcell.NewRGBColor(1, 2, 3)),
+		},
+		{
+			in:   tcell.Style{},
+			ansi: []int{1, 38, 5, 196, 48, 2, 0, 0, 0, 4},
+			out: (tcell.Style{}).
+				Foreground(tcell.PaletteColor(196)).
+				Background(tcell.NewRGBColor(0, 0, 0)).
+				Attributes(tcell.AttrBold | tcell.AttrUnderline),
+		},
+		{
+			in:   tcell.Style{},
+			ansi: []int{38, 5, 300, 4},
+			out:  (tcell.Style{}).Attributes(tcell.AttrUnderline),
+		},
+		{
+			in:   tcell.Style{},
+			ansi: []int{38, 5},
+			out:  tcell.Style{},
+		},
+		{
+			in:   tcell.Style{},
+			ansi: []int{38, 2, 1, 2},
+			out:  tcell.Style{},
+		},
+		{
+			in:   tcell.Style{},
+			ansi: []int{38, 7, 4},
+			out:  (tcell.Style{}).Attributes(tcell.AttrUnderline),
+		},
 	}
 
 	for i, tc := range tcs {
 		t.Run(fmt.Sprintf("case %d", i), func(t *testing.T) {
 			assert := assert.New(t)
-			assert.Equal(tc.out, tui.ApplyANSI(tc.in, tc.ansi))
+			assert.Equal(tc.out, tui.ApplyANSI(tc.in, tc.ansi...))
 		})
 	}
 }
@@ -0,0 +1,84 @@
+package tui
+
+import (
+	"fmt"
+	"hash/fnv"
+	"strings"
+
+	"github.com/tobiassjosten/nogfx/pkg"
+)
+
+// Number of styles to tag channels with, named "comm.0" and onwards in themes.
+const commStyles = 8
+
+// AddCommunication adds a message to the communications pane and causes a
+// repaint.
+func (tui *TUI) AddCommunication(comm pkg.Communication) {
+	row := NewCommRow(comm, tui.theme)
+
+	tui.commMutex.Lock()
+	tui.comm.AppendRow(row)
+	tui.commMutex.Unlock()
+
+	tui.setCache(paneComm, nil)
+	tui.Draw()
+}
+
+// NewCommRow creates a Row from the given Communication, prefixed with a
+// colored tag of the channel.
+func NewCommRow(comm pkg.Communication, theme *Theme) Row {
+	style := theme.Style(commStyle(comm.Channel))
+
+	row := NewRowFromRunes([]rune("["+comm.Channel+"]"), style)
+	row = row.append(NewCell(' '))
+
+	text, _ := NewRowFromBytes(comm.Text)
+
+	return row.append(text...)
+}
+
+// commStyle picks a style name for the given channel, grouping all channels of
+// the same kind (e.g. "tell Durak" and "tell Sena").
+func commStyle(channel string) string {
+	if fields := strings.Fields(channel); len(fields) > 0 {
+		channel = fields[0]
+	}
+
+	hash := fnv.New32a()
+	_, _ = hash.Write([]byte(channel))
+
+	return fmt.Sprintf("comm.%d", hash.Sum32()%commStyles)
+}
+
+// scrollComm scrolls the communications back in history, or forward for
+// negative numbers of lines, but not past the most recent.
+func (tui *TUI) scrollComm(lines int) {
+	tui.setCache(paneComm, nil)
+
+	tui.commMutex.Lock()
+	tui.comm.offset = max(0, tui.comm.offset+lines)
+	tui.commMutex.Unlock()
+}
+
+// hasComm tells whether there are any communications to show.
+func (tui *TUI) hasComm() bool {
+	tui.commMutex.Lock()
+	defer tui.commMutex.Unlock()
+
+	return len(tui.comm.buffer) > 0
+}
+
+// RenderComm renders the current communications.
+func (tui *TUI) RenderComm(width, height int) Rows {
+	if rows, ok := tui.getCache(paneComm); ok {
+		return rows
+	}
+
+	tui.commMutex.Lock()
+	rows := RenderOutput(tui.comm, width, height)
+	tui.commMutex.Unlock()
+
+	tui.setCache(paneComm, rows)
+
+	return rows
+}
@@ -0,0 +1,63 @@
+package tui
+
+import (
+	"testing"
+
+	"github.com/tobiassjosten/nogfx/pkg"
+	"github.com/tobiassjosten/nogfx/pkg/mock"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewCommRow(t *testing.T) {
+	tagStyle := DefaultTheme().Style(commStyle("ct"))
+	textStyle := (tcell.Style{}).Foreground(tcell.ColorGreen)
+
+	row := NewCommRow(pkg.Communication{
+		Channel: "ct",
+		Talker:  "Durak",
+		Text:    []byte("\033[32mhi"),
+	}, DefaultTheme())
+
+	assert.Equal(t, Row{
+		NewCell('[', tagStyle),
+		NewCell('c', tagStyle),
+		NewCell('t', tagStyle),
+		NewCell(']', tagStyle),
+		NewCell(' '),
+		NewCell('h', textStyle),
+		NewCell('i', textStyle),
+	}, row)
+}
+
+func TestCommStyle(t *testing.T) {
+	assert.Equal(t, commStyle("tell Durak"), commStyle("tell Sena"))
+	assert.Equal(t, commStyle("tell"), commStyle("tell Durak"))
+
+	_, ok := DefaultTheme().Lookup(commStyle("ct"))
+	assert.True(t, ok)
+}
+
+func TestRenderComm(t *testing.T) {
+	ui := NewTUI(&mock.ScreenMock{
+		HideCursorFunc:     func() {},
+		SetCursorStyleFunc: func(_ tcell.CursorStyle) {},
+		SetStyleFunc:       func(_ tcell.Style) {},
+	})
+
+	ui.AddCommunication(pkg.Communication{Channel: "ct", Text: []byte("a")})
+	ui.AddCommunication(pkg.Communication{Channel: "ct", Text: []byte("b")})
+
+	assert.Equal(t,
+		[]string{"\u00a0\u00a0\u00a0\u00a0\u00a0\u00a0", "[ct] a", "[ct] b"},
+		ui.RenderComm(6, 3).Strings(),
+	)
+
+	ui.comm.offset = 1
+	ui.AddCommunication(pkg.Communication{Channel: "ct", Text: []byte("c")})
+
+	// New communications invalidate the cache and push history further
+	// back, when scrolled.
+	assert.Equal(t, 2, ui.comm.offset)
+}
@@ -4,8 +4,8 @@ import (
 	"github.com/gdamore/tcell/v2"
 )
 
-// @todo Map more keys, like 271 (delete), 268 (home), 269 (end), 267 (pgdn),
-// 266 (pgup), and alt/cmd+left/right.
+// @todo Map more keys, like 271 (delete), 268 (home), 269 (end), and
+// alt/cmd+left/right.
 
 func (tui *TUI) eventHandlers() map[int]func(rune) bool {
 	alt := int(tcell.ModAlt)
@@ -28,6 +28,15 @@ func (tui *TUI) eventHandlers() map[int]func(rune) bool {
 		int(tcell.KeyDown):       tui.handleDownInput,
 		int(tcell.KeyDown) + alt: tui.handleAltDownInput,
 
+		int(tcell.KeyPgUp): tui.handlePgUpInput,
+		int(tcell.KeyPgDn): tui.handlePgDnInput,
+
+		int(tcell.KeyF2):  tui.handleF2Input,
+		int(tcell.KeyF3):  tui.handleF3Input,
+		int(tcell.KeyF4):  tui.handleF4Input,
+		int(tcell.KeyF5):  tui.handleF5Input,
+		int(tcell.KeyF12): tui.handleF12Input,
+
 		int(keyNum1): tui.handleNum1,
 		int(keyNum2): tui.handleNum2,
 		int(keyNum3): tui.handleNum3,
@@ -41,6 +50,18 @@ func (tui *TUI) eventHandlers() map[int]func(rune) bool {
 
 // HandleEvent reacts on a user event and modifies itself from it.
 func (tui *TUI) HandleEvent(event *tcell.EventKey) bool {
+	if tui.browser != nil && event.Key() != tcell.KeyF2 && event.Key() != tcell.KeyF12 {
+		return tui.handleBrowserEvent(event)
+	}
+
+	if tui.skillBrowser != nil && event.Key() != tcell.KeyF3 && event.Key() != tcell.KeyF12 {
+		return tui.handleSkillBrowserEvent(event)
+	}
+
+	if tui.inspector != nil && event.Key() != tcell.KeyF5 && event.Key() != tcell.KeyF12 {
+		return tui.handleInspectorEvent(event)
+	}
+
 	// List alternatives in order of specificity (descending).
 	alts := []int{
 		int(event.Rune()) + int(event.Key()),
@@ -174,6 +195,48 @@ func (tui *TUI) handleEscInput(_ rune) bool {
 	tui.setCache(paneInput, nil)
 	tui.setCache(paneOutput, nil)
 	tui.output.offset = 0
+	tui.setCache(paneComm, nil)
+	tui.commMutex.Lock()
+	tui.comm.offset = 0
+	tui.commMutex.Unlock()
+
+	return true
+}
+
+// handleF2Input toggles the full-screen map browser.
+func (tui *TUI) handleF2Input(_ rune) bool {
+	return tui.ToggleBrowser()
+}
+
+// handleF3Input toggles the full-screen skills browser.
+func (tui *TUI) handleF3Input(_ rune) bool {
+	return tui.ToggleSkillBrowser()
+}
+
+// handleF4Input toggles the character sheet.
+func (tui *TUI) handleF4Input(_ rune) bool {
+	return tui.ToggleSheet()
+}
+
+// handleF5Input toggles the full-screen GMCP inspector.
+func (tui *TUI) handleF5Input(_ rune) bool {
+	return tui.ToggleInspector()
+}
+
+// handleF12Input cycles through the built-in themes.
+func (tui *TUI) handleF12Input(_ rune) bool {
+	themes := tui.themes()
+	next := 0
+
+	for i, theme := range themes {
+		if theme == tui.theme {
+			next = (i + 1) % len(themes)
+			break
+		}
+	}
+
+	tui.theme = themes[next]
+	tui.clearCache()
 
 	return true
 }
@@ -239,6 +302,18 @@ func (tui *TUI) handleAltDownInput(_ rune) bool {
 	return true
 }
 
+func (tui *TUI) handlePgUpInput(_ rune) bool {
+	tui.scrollComm(5)
+
+	return true
+}
+
+func (tui *TUI) handlePgDnInput(_ rune) bool {
+	tui.scrollComm(-5)
+
+	return true
+}
+
 func (tui *TUI) handleNum1(_ rune) bool {
 	tui.inputs <- []byte{'s', 'w'}
 	return true
@@ -349,6 +349,63 @@ func TestHandleEvent(t *testing.T) {
 			},
 		},
 
+		"f12 cycles themes": {
+			events: []*tcell.EventKey{
+				tcell.NewEventKey(tcell.KeyF12, 0, 0),
+				tcell.NewEventKey(tcell.KeyF12, 0, 0),
+			},
+			setup: func(ui *TUI) {
+				ui.setCache(paneInput, Rows{})
+			},
+			test: func(a *assert.Assertions, ui *TUI) {
+				a.Equal(ThemeHighContrast, ui.theme.Name)
+
+				_, ok := ui.getCache(paneInput)
+				a.False(ok)
+			},
+		},
+
+		"f12 cycles from custom theme to the first": {
+			events: []*tcell.EventKey{
+				tcell.NewEventKey(tcell.KeyF12, 0, 0),
+			},
+			setup: func(ui *TUI) {
+				ui.SetTheme(&Theme{Name: "custom"})
+			},
+			test: func(a *assert.Assertions, ui *TUI) {
+				a.Equal(ThemeDark, ui.theme.Name)
+			},
+		},
+
+		"f12 cycles back to custom theme": {
+			events: []*tcell.EventKey{
+				tcell.NewEventKey(tcell.KeyF12, 0, 0),
+				tcell.NewEventKey(tcell.KeyF12, 0, 0),
+				tcell.NewEventKey(tcell.KeyF12, 0, 0),
+				tcell.NewEventKey(tcell.KeyF12, 0, 0),
+				tcell.NewEventKey(tcell.KeyF12, 0, 0),
+			},
+			setup: func(ui *TUI) {
+				ui.SetTheme(&Theme{Name: "custom"})
+			},
+			test: func(a *assert.Assertions, ui *TUI) {
+				a.Equal("custom", ui.theme.Name)
+			},
+		},
+
+		"f12 doesn't cycle built-in themes twice": {
+			events: []*tcell.EventKey{
+				tcell.NewEventKey(tcell.KeyF12, 0, 0),
+			},
+			setup: func(ui *TUI) {
+				ui.SetTheme(DefaultTheme())
+			},
+			test: func(a *assert.Assertions, ui *TUI) {
+				a.Nil(ui.customTheme)
+				a.Equal(ThemeLight, ui.theme.Name)
+			},
+		},
+
 		"unknown keys dont do anything": {
 			events: []*tcell.EventKey{
 				tcell.NewEventKey(tcell.KeyCtrlP, 0, 0),
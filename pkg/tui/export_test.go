@@ -0,0 +1,21 @@
+package tui
+
+import "time"
+
+// SetDeltaDuration changes how long changes of vitals are shown, returning a
+// function restoring the previous duration.
+func SetDeltaDuration(duration time.Duration) func() {
+	previous := deltaDuration
+	deltaDuration = duration
+
+	return func() {
+		deltaDuration = previous
+	}
+}
+
+// RenderVitalsAnew renders the vitals without relying on the cache, for tests
+// watching them change from a timer.
+func (tui *TUI) RenderVitalsAnew(width int) Rows {
+	tui.setCache(paneVitals, nil)
+	return tui.RenderVitals(width)
+}
@@ -37,7 +37,7 @@ func (tui *TUI) RenderInput(width, height int) (rows Rows, x int, y int) {
 		return rows, tui.input.cursorpos[0], tui.input.cursorpos[1]
 	}
 
-	rows, cx, cy := RenderInput(tui.input, width, height)
+	rows, cx, cy := RenderInput(tui.input, width, height, tui.theme.Style("input"))
 
 	tui.setCache(paneInput, rows)
 	tui.input.cursorpos = []int{cx, cy}
@@ -46,17 +46,13 @@ func (tui *TUI) RenderInput(width, height int) (rows Rows, x int, y int) {
 }
 
 // RenderInput renders the given Input.
-func RenderInput(input *Input, width, height int) (rows Rows, x int, y int) {
+func RenderInput(input *Input, width, height int, style tcell.Style) (rows Rows, x int, y int) {
 	if width == 0 {
 		return nil, 0, 0
 	}
 
-	style := (tcell.Style{}).
-		Foreground(tcell.ColorWhite).
-		Background(tcell.Color235)
-
 	if input.inputted {
-		style = style.Attributes(tcell.AttrDim)
+		style = style.Dim(true)
 	}
 
 	padding := NewCell(nbsp, style)
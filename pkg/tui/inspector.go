@@ -0,0 +1,240 @@
+package tui
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/tobiassjosten/nogfx/pkg"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+// How many GMCP messages to keep for inspection.
+const inspectorLimit = 1000
+
+const inspectorHelp = "up/down/pgup/pgdn scroll, / filter, esc close"
+
+// Inspector is a full-screen listing of the GMCP messages sent to and received
+// from the game, for debugging.
+type Inspector struct {
+	filtering bool
+	filter    []rune
+	query     []rune
+
+	// How many lines up from the most recent the listing is scrolled.
+	offset int
+}
+
+// inspectorEntry is a GMCP message formatted for the inspector, once, so that
+// redrawing doesn't mean formatting every message anew.
+type inspectorEntry struct {
+	id       string
+	outgoing bool
+
+	// The time, direction and ID, followed by the data as indented JSON.
+	lines []string
+}
+
+// inspectorLine is a line of the inspector, with the name of its style.
+type inspectorLine struct {
+	text  string
+	style string
+}
+
+// AddGMCP records a GMCP message for inspection and causes a repaint if the
+// inspector is shown.
+func (tui *TUI) AddGMCP(entry pkg.GMCPEntry) {
+	formatted := formatInspectorEntry(entry)
+
+	tui.gmcpMutex.Lock()
+	tui.gmcp = append(tui.gmcp, formatted)
+	if len(tui.gmcp) > inspectorLimit {
+		tui.gmcp = tui.gmcp[len(tui.gmcp)-inspectorLimit:]
+	}
+	tui.gmcpMutex.Unlock()
+
+	if tui.inspector != nil {
+		tui.Draw()
+	}
+}
+
+// formatInspectorEntry lays out a message with its time, direction and ID
+// followed by its data as indented JSON.
+func formatInspectorEntry(entry pkg.GMCPEntry) inspectorEntry {
+	direction := "<-"
+	if entry.Outgoing {
+		direction = "->"
+	}
+
+	lines := []string{fmt.Sprintf(
+		"%s %s %s", entry.Time.Format("15:04:05.000"), direction, entry.ID,
+	)}
+
+	if len(entry.Data) > 0 {
+		data := entry.Data
+
+		var indented bytes.Buffer
+		if err := json.Indent(&indented, entry.Data, "  ", "  "); err == nil {
+			data = indented.Bytes()
+		}
+
+		lines = append(lines, strings.Split("  "+string(data), "\n")...)
+	}
+
+	return inspectorEntry{
+		id:       entry.ID,
+		outgoing: entry.Outgoing,
+		lines:    lines,
+	}
+}
+
+// ToggleInspector shows or hides the GMCP inspector.
+func (tui *TUI) ToggleInspector() bool {
+	tui.clear = true
+	tui.clearCache()
+
+	if tui.inspector != nil {
+		tui.inspector = nil
+		return true
+	}
+
+	tui.inspector = &Inspector{}
+
+	return true
+}
+
+// handleInspectorEvent reacts to keys while the GMCP inspector is shown.
+func (tui *TUI) handleInspectorEvent(ev *tcell.EventKey) bool {
+	inspector := tui.inspector
+
+	if inspector.filtering {
+		switch ev.Key() {
+		case tcell.KeyEsc:
+			inspector.filtering = false
+
+		case tcell.KeyBackspace, tcell.KeyBackspace2:
+			if len(inspector.query) > 0 {
+				inspector.query = inspector.query[:len(inspector.query)-1]
+			}
+
+		case tcell.KeyEnter:
+			inspector.filtering = false
+			inspector.filter = inspector.query
+			inspector.offset = 0
+
+		case tcell.KeyRune:
+			inspector.query = append(inspector.query, ev.Rune())
+		}
+
+		return true
+	}
+
+	_, height := tui.screen.Size()
+	page := max(1, height-2)
+
+	switch ev.Key() {
+	case tcell.KeyEsc:
+		return tui.ToggleInspector()
+
+	case tcell.KeyUp:
+		inspector.offset++
+
+	case tcell.KeyDown:
+		inspector.offset = max(0, inspector.offset-1)
+
+	case tcell.KeyPgUp:
+		inspector.offset += page
+
+	case tcell.KeyPgDn:
+		inspector.offset = max(0, inspector.offset-page)
+
+	case tcell.KeyEnd:
+		inspector.offset = 0
+
+	case tcell.KeyRune:
+		if ev.Rune() == '/' {
+			inspector.filtering = true
+			inspector.query = []rune{}
+		}
+	}
+
+	return true
+}
+
+// RenderInspector renders the GMCP inspector, with the most recent messages
+// at the bottom.
+func (tui *TUI) RenderInspector(width, height int) Rows {
+	if tui.inspector == nil || width == 0 || height < 3 {
+		return Rows{}
+	}
+
+	inspector := tui.inspector
+	blank := NewCell(' ')
+
+	header := "GMCP inspector"
+	if len(inspector.filter) > 0 {
+		header += fmt.Sprintf(" (%s)", string(inspector.filter))
+	}
+
+	footer := inspectorHelp
+	if inspector.filtering {
+		footer = "/" + string(inspector.query)
+	}
+
+	lines := tui.inspectorLines()
+
+	body := height - 2
+	inspector.offset = min(inspector.offset, max(0, len(lines)-body))
+
+	end := len(lines) - inspector.offset
+	lines = lines[max(0, end-body):end]
+
+	visible := Rows{}
+
+	for _, line := range lines {
+		row := NewRowFromRunes([]rune(line.text), tui.theme.Style(line.style))
+		if len(row) > width {
+			row = row[:width]
+		}
+
+		visible = append(visible, row.Pad(width, blank))
+	}
+
+	rows := Rows{browserRow(header, width, tui.theme.Style("browser.header"))}
+	rows = append(rows, NewRows(width, body-len(visible), blank)...)
+	rows = append(rows, visible...)
+	rows = append(rows, browserRow(footer, width, tui.theme.Style("browser.footer")))
+
+	return rows
+}
+
+// inspectorLines lists the lines of the messages matching the filter.
+func (tui *TUI) inspectorLines() []inspectorLine {
+	filter := strings.ToLower(string(tui.inspector.filter))
+
+	tui.gmcpMutex.Lock()
+	defer tui.gmcpMutex.Unlock()
+
+	lines := []inspectorLine{}
+
+	for _, entry := range tui.gmcp {
+		if !strings.HasPrefix(strings.ToLower(entry.id), filter) {
+			continue
+		}
+
+		style := "inspector.in"
+		if entry.outgoing {
+			style = "inspector.out"
+		}
+
+		lines = append(lines, inspectorLine{entry.lines[0], style})
+
+		for _, line := range entry.lines[1:] {
+			lines = append(lines, inspectorLine{line, "inspector.data"})
+		}
+	}
+
+	return lines
+}
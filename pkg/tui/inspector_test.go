@@ -0,0 +1,105 @@
+package tui
+
+import (
+	"testing"
+	"time"
+
+	"github.com/tobiassjosten/nogfx/pkg"
+	"github.com/tobiassjosten/nogfx/pkg/mock"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func inspectorTUI() *TUI {
+	ui := NewTUI(&mock.ScreenMock{
+		SetCursorStyleFunc: func(_ tcell.CursorStyle) {},
+		SetStyleFunc:       func(_ tcell.Style) {},
+		SizeFunc:           func() (int, int) { return 30, 6 },
+	})
+
+	at := time.Date(2023, 5, 1, 12, 34, 56, 789000000, time.UTC)
+
+	ui.AddGMCP(pkg.GMCPEntry{Time: at, ID: "Char.Name", Data: []byte(`{"name":"Durak"}`)})
+	ui.AddGMCP(pkg.GMCPEntry{Time: at, ID: "Char.Items.Inv", Outgoing: true})
+	ui.AddGMCP(pkg.GMCPEntry{Time: at, ID: "IRE.Rift.List", Data: []byte(`[]`)})
+
+	return ui
+}
+
+func TestInspectorToggle(t *testing.T) {
+	ui := inspectorTUI()
+
+	assert.True(t, ui.HandleEvent(key(tcell.KeyF5)))
+	require.NotNil(t, ui.inspector)
+
+	// Keys go to the inspector instead of the input while it's shown.
+	ui.HandleEvent(runes("x")[0])
+	assert.Empty(t, ui.input.buffer)
+
+	assert.True(t, ui.HandleEvent(key(tcell.KeyEsc)))
+	assert.Nil(t, ui.inspector)
+}
+
+func TestInspectorLimit(t *testing.T) {
+	ui := inspectorTUI()
+
+	for i := 0; i < inspectorLimit; i++ {
+		ui.AddGMCP(pkg.GMCPEntry{ID: "Core.Ping"})
+	}
+
+	assert.Len(t, ui.gmcp, inspectorLimit)
+	assert.Equal(t, "Core.Ping", ui.gmcp[0].id)
+}
+
+func TestRenderInspector(t *testing.T) {
+	ui := inspectorTUI()
+	assert.Equal(t, Rows{}, ui.RenderInspector(30, 8))
+
+	ui.ToggleInspector()
+
+	rows := ui.RenderInspector(30, 9)
+	require.Len(t, rows, 9)
+
+	assert.Equal(t, []string{
+		"GMCP inspector                ",
+		"12:34:56.789 <- Char.Name     ",
+		"  {                           ",
+		`    "name": "Durak"           `,
+		"  }                           ",
+		"12:34:56.789 -> Char.Items.Inv",
+		"12:34:56.789 <- IRE.Rift.List ",
+		"  []                          ",
+		string([]rune(inspectorHelp)[:30]),
+	}, rows.Strings())
+	assert.Equal(t, DefaultTheme().Style("inspector.in"), rows[1][0].Style)
+	assert.Equal(t, DefaultTheme().Style("inspector.out"), rows[5][0].Style)
+
+	// Scrolling up reveals older messages.
+	ui.HandleEvent(key(tcell.KeyUp))
+	ui.HandleEvent(key(tcell.KeyUp))
+
+	rows = ui.RenderInspector(30, 5)
+	assert.Equal(t, []string{
+		"GMCP inspector                ",
+		`    "name": "Durak"           `,
+		"  }                           ",
+		"12:34:56.789 -> Char.Items.Inv",
+		string([]rune(inspectorHelp)[:30]),
+	}, rows.Strings())
+
+	// Filtering goes back to the most recent messages.
+	for _, event := range append(runes("/char"), key(tcell.KeyEnter)) {
+		ui.HandleEvent(event)
+	}
+
+	rows = ui.RenderInspector(30, 5)
+	assert.Equal(t, []string{
+		"GMCP inspector (char)         ",
+		`    "name": "Durak"           `,
+		"  }                           ",
+		"12:34:56.789 -> Char.Items.Inv",
+		string([]rune(inspectorHelp)[:30]),
+	}, rows.Strings())
+}
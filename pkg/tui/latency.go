@@ -0,0 +1,87 @@
+package tui
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/tobiassjosten/nogfx/pkg"
+)
+
+// Blocks of increasing height, for drawing sparklines.
+var sparks = []rune("▁▂▃▄▅▆▇█")
+
+// SetLatency updates the health of the connection and causes a repaint.
+func (tui *TUI) SetLatency(latency pkg.Latency) {
+	tui.latency = latency
+	tui.setCache(paneLatency, nil)
+	tui.Draw()
+}
+
+// RenderLatency renders a status line with the latest round-trip time and a
+// sparkline of those before it, preceded by a warning if the game has gone
+// silent.
+func (tui *TUI) RenderLatency(width int) Rows {
+	if rows, ok := tui.getCache(paneLatency); ok {
+		return rows
+	}
+
+	if width == 0 {
+		return Rows{}
+	}
+
+	row := Row{}
+
+	if silence := tui.latency.Silence; silence > 0 {
+		row = NewRowFromRunes([]rune(fmt.Sprintf(
+			"No data in %s", silence.Round(time.Second),
+		)), tui.theme.Style("latency.warning"))
+		row = row.append(NewCell(' '), NewCell(' '))
+	}
+
+	if history := tui.latency.History; len(history) > 0 {
+		row = row.append(NewRowFromRunes([]rune(fmt.Sprintf(
+			"%dms ", tui.latency.Current().Milliseconds(),
+		)), tui.theme.Style("latency"))...)
+
+		if avail := width - len(row); len(history) > avail {
+			history = history[len(history)-max(0, avail):]
+		}
+
+		row = row.append(NewRowFromRunes(
+			sparkline(history), tui.theme.Style("latency.sparkline"),
+		)...)
+	}
+
+	if len(row) > width {
+		row = row[:width]
+	}
+
+	rows := Rows{row.Pad(width, NewCell(' '))}
+
+	tui.setCache(paneLatency, rows)
+
+	return rows
+}
+
+// sparkline draws durations as blocks, scaled relative to the longest one.
+func sparkline(durations []time.Duration) []rune {
+	var longest time.Duration
+	for _, duration := range durations {
+		if duration > longest {
+			longest = duration
+		}
+	}
+
+	runes := make([]rune, len(durations))
+
+	for i, duration := range durations {
+		level := 0
+		if longest > 0 {
+			level = int(duration * time.Duration(len(sparks)-1) / longest)
+		}
+
+		runes[i] = sparks[level]
+	}
+
+	return runes
+}
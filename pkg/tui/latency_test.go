@@ -0,0 +1,82 @@
+package tui
+
+import (
+	"testing"
+	"time"
+
+	"github.com/tobiassjosten/nogfx/pkg"
+	"github.com/tobiassjosten/nogfx/pkg/mock"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRenderLatency(t *testing.T) {
+	ms := time.Millisecond
+
+	tcs := map[string]struct {
+		latency pkg.Latency
+		width   int
+		rows    []string
+	}{
+		"nothing": {
+			width: 10,
+			rows:  []string{"          "},
+		},
+
+		"history": {
+			latency: pkg.Latency{History: []time.Duration{10 * ms, 80 * ms, 40 * ms}},
+			width:   12,
+			rows:    []string{"40ms ▁█▄    "},
+		},
+
+		"cramped": {
+			latency: pkg.Latency{History: []time.Duration{80 * ms, 10 * ms, 40 * ms}},
+			width:   7,
+			rows:    []string{"40ms ▂█"},
+		},
+
+		"silence": {
+			latency: pkg.Latency{
+				History: []time.Duration{40 * ms},
+				Silence: 90 * time.Second,
+			},
+			width: 24,
+			rows:  []string{"No data in 1m30s  40ms █"},
+		},
+	}
+
+	for name, tc := range tcs {
+		t.Run(name, func(t *testing.T) {
+			ui := NewTUI(&mock.ScreenMock{
+				HideCursorFunc:     func() {},
+				SetCursorStyleFunc: func(_ tcell.CursorStyle) {},
+				SetStyleFunc:       func(_ tcell.Style) {},
+			})
+
+			ui.SetLatency(tc.latency)
+
+			rows := ui.RenderLatency(tc.width)
+			assert.Equal(t, tc.rows, rows.Strings())
+		})
+	}
+}
+
+func TestRenderLatencyStyles(t *testing.T) {
+	ui := NewTUI(&mock.ScreenMock{
+		HideCursorFunc:     func() {},
+		SetCursorStyleFunc: func(_ tcell.CursorStyle) {},
+		SetStyleFunc:       func(_ tcell.Style) {},
+	})
+
+	ui.SetLatency(pkg.Latency{
+		History: []time.Duration{time.Millisecond},
+		Silence: time.Minute,
+	})
+
+	rows := ui.RenderLatency(24)
+
+	assert.Equal(t, darkTheme.Style("latency.warning"), rows[0][0].Style)
+	assert.Equal(t, darkTheme.Style("latency"), rows[0][17].Style)
+	assert.Equal(t, darkTheme.Style("latency.sparkline"), rows[0][21].Style)
+}
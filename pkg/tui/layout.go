@@ -1,5 +1,11 @@
 package tui
 
+import (
+	"fmt"
+
+	"github.com/tobiassjosten/nogfx/pkg"
+)
+
 const (
 	mainMinWidth = 80
 	mainMaxWidth = 120
@@ -15,32 +21,67 @@ const (
 	sideMinWidth = minimapRoomWidth*3 + minimapRoomsMargin
 	mapMinHeight = minimapRoomHeight*3 + minimapRoomsMargin
 
-	mainSideMinWidth = mainMinWidth + borderWidth + sideMinWidth
-	mainSideMaxWidth = mainMinWidth + borderWidth + (mainMaxWidth-mainMinWidth)*2
-
-	paneInput  = "input"
-	paneMain   = "main"
-	paneMap    = "map"
-	paneOutput = "output"
-	paneScreen = "screen"
-	paneSide   = "side"
-	paneTarget = "target"
-	paneVitals = "vitals"
+	commMinHeight = 3
+
+	splitColumns = "columns"
+	splitRows    = "rows"
+
+	paneBlank       = ""
+	paneAfflictions = "afflictions"
+	paneComm        = "comm"
+	paneInput       = "input"
+	paneLatency     = "latency"
+	paneMap         = "map"
+	paneOccupants   = "occupants"
+	paneOutput      = "output"
+	paneRift        = "rift"
+	paneSheet       = "sheet"
+	paneStatus      = "status"
+	paneTarget      = "target"
+	paneVitals      = "vitals"
 )
 
-var paneNames = []string{
-	paneInput,
-	paneMain,
-	paneMap,
-	paneOutput,
-	paneScreen,
-	paneSide,
-	paneTarget,
-	paneVitals,
+// DefaultLayout is the built-in layout, with a main column of game output,
+// player input, connection latency and the status bar, accompanied by a side
+// column with afflictions, the character sheet when toggled, the minimap, room
+// occupants and the rift.
+func DefaultLayout() *pkg.LayoutConfig {
+	return &pkg.LayoutConfig{
+		Split: splitColumns,
+		Gap:   borderWidth,
+		Children: []pkg.LayoutConfig{
+			{
+				Split: splitRows,
+				Min:   mainMinWidth,
+				Max:   mainMaxWidth,
+				Children: []pkg.LayoutConfig{
+					{Pane: paneOutput},
+					{Pane: paneVitals},
+					{Pane: paneInput},
+					{Pane: paneTarget},
+					{Pane: paneLatency},
+					{Pane: paneStatus},
+				},
+			},
+			{
+				Split: splitRows,
+				Min:   sideMinWidth,
+				Children: []pkg.LayoutConfig{
+					{Pane: paneBlank, Min: borderWidth, Max: borderWidth},
+					{Pane: paneAfflictions},
+					{Pane: paneSheet},
+					{Pane: paneMap, Min: mapMinHeight},
+					{Pane: paneOccupants},
+					{Pane: paneRift},
+					{Pane: paneComm, Min: commMinHeight},
+				},
+			},
+		},
+	}
 }
 
 type pane struct {
-	rows   Rows // null == virtual pane (dimensions holder)
+	rows   Rows
 	x      int
 	y      int
 	width  int
@@ -55,169 +96,386 @@ func newpane(rows Rows, x, y int) pane {
 	return pane{rows, x, y, len(rows[0]), len(rows)}
 }
 
-// Layout orchestrates all the panes, to determine which one goes where with
-// what dimensions.
-type Layout struct {
-	tui *TUI
-}
+// paneKind describes how a type of pane is rendered and sized.
+type paneKind struct {
+	render func(width, height int) Rows
 
-func (l *Layout) panes() []pane {
-	panes := []pane{}
-	for _, name := range paneNames {
-		panes = append(panes, l.pane(name))
-	}
+	// Fitted panes are sized by their content, rather than filling out
+	// the available space.
+	fit bool
 
-	return panes
+	// Empty panes have nothing to show and are left out of the layout.
+	empty func() bool
 }
 
-func (l *Layout) pane(name string) pane {
-	width, height := l.tui.screen.Size()
-
-	switch name {
-	case paneInput:
-		return l.inputPane()
-
-	case paneMain:
-		return l.mainPane()
+// paneKinds maps pane names to their kinds, for a layout to look them up.
+func (tui *TUI) paneKinds() map[string]paneKind {
+	return map[string]paneKind{
+		paneBlank: {},
+
+		paneAfflictions: {
+			render: tui.RenderAfflictions,
+			fit:    true,
+			empty: func() bool {
+				return len(tui.character.Afflictions) == 0 &&
+					len(tui.character.MissingDefences) == 0
+			},
+		},
+
+		paneComm: {
+			render: tui.RenderComm,
+			empty: func() bool {
+				return !tui.hasComm()
+			},
+		},
+
+		paneInput: {
+			render: func(width, height int) Rows {
+				rows, _, _ := tui.RenderInput(width, height)
+				return rows
+			},
+			fit: true,
+		},
+
+		paneLatency: {
+			render: func(width, _ int) Rows {
+				return tui.RenderLatency(width)
+			},
+			fit: true,
+			empty: func() bool {
+				return len(tui.latency.History) == 0 && tui.latency.Silence == 0
+			},
+		},
+
+		paneMap: {
+			render: tui.RenderMap,
+		},
+
+		paneOccupants: {
+			render: tui.RenderOccupants,
+			fit:    true,
+			empty: func() bool {
+				return tui.occupants.Empty()
+			},
+		},
+
+		paneOutput: {
+			render: tui.RenderOutput,
+		},
+
+		paneRift: {
+			render: tui.RenderRift,
+			fit:    true,
+			empty: func() bool {
+				return len(tui.rift) == 0
+			},
+		},
+
+		paneSheet: {
+			render: tui.RenderSheet,
+			fit:    true,
+			empty: func() bool {
+				return !tui.sheet || len(tui.character.Sheet) == 0
+			},
+		},
+
+		paneStatus: {
+			render: func(width, _ int) Rows {
+				return tui.RenderStatus(width)
+			},
+			fit: true,
+			empty: func() bool {
+				return tui.status.Address == ""
+			},
+		},
+
+		paneTarget: {
+			render: func(width, _ int) Rows {
+				return tui.RenderTarget(width)
+			},
+			fit: true,
+		},
+
+		paneVitals: {
+			render: func(width, _ int) Rows {
+				return tui.RenderVitals(width)
+			},
+			fit: true,
+		},
+	}
+}
 
-	case paneMap:
-		return l.mapPane()
+// SetLayout changes how panes are laid out, with nil restoring the default.
+func (tui *TUI) SetLayout(config *pkg.LayoutConfig) error {
+	if config == nil {
+		config = DefaultLayout()
+	}
 
-	case paneOutput:
-		return l.outputPane()
+	layout := newLayout(tui, config)
+	if err := layout.validate(*config); err != nil {
+		return fmt.Errorf("invalid layout: %w", err)
+	}
 
-	case paneScreen:
-		return pane{nil, 0, 0, width, height}
+	tui.layout = layout
+	tui.clearCache()
+	tui.Draw()
 
-	case paneSide:
-		return l.sidePane()
+	return nil
+}
 
-	case paneTarget:
-		return l.targetPane()
+func (l *Layout) validate(node pkg.LayoutConfig) error {
+	if node.Min < 0 || node.Max < 0 || node.Gap < 0 {
+		return fmt.Errorf("negative size for '%s%s'", node.Split, node.Pane)
+	}
 
-	case paneVitals:
-		return l.vitalsPane()
+	switch node.Split {
+	case "":
+		if _, ok := l.kinds[node.Pane]; !ok {
+			return fmt.Errorf("unknown pane '%s'", node.Pane)
+		}
+
+		if len(node.Children) > 0 {
+			return fmt.Errorf("pane '%s' can't have children", node.Pane)
+		}
+
+	case splitColumns, splitRows:
+		if node.Pane != "" {
+			return fmt.Errorf("split '%s' can't be pane '%s'", node.Split, node.Pane)
+		}
+
+		for _, child := range node.Children {
+			if err := l.validate(child); err != nil {
+				return err
+			}
+		}
+
+	default:
+		return fmt.Errorf("unknown split '%s'", node.Split)
 	}
 
-	return pane{}
+	return nil
 }
 
-func (l *Layout) inputPane() pane {
-	main := l.pane(paneMain)
-	target := l.pane(paneTarget)
-
-	vitalsHeight := 0
-	if main.height >= 5 {
-		vitalsHeight = 1
-	}
+// Layout orchestrates all the panes, to determine which one goes where with
+// what dimensions.
+type Layout struct {
+	tui    *TUI
+	config *pkg.LayoutConfig
+	kinds  map[string]paneKind
 
-	// Split half remaining with output, rounding down to yield presedence.
-	maxHeight := (main.height - target.height - vitalsHeight) / 2
+	// Dimensions that panes were last rendered with, to know when their
+	// cached renditions are outdated.
+	dimensions map[string][2]int
+}
 
-	rows, cx, cy := l.tui.RenderInput(main.width, maxHeight)
+func newLayout(tui *TUI, config *pkg.LayoutConfig) *Layout {
+	return &Layout{
+		tui:        tui,
+		config:     config,
+		kinds:      tui.paneKinds(),
+		dimensions: map[string][2]int{},
+	}
+}
 
-	x := main.x
-	y := main.y + main.height - len(rows) - target.height
+func (l *Layout) panes() []pane {
+	width, height := l.tui.screen.Size()
 
-	l.tui.cursorpos = []int{x + cx, y + cy}
+	// The input pane sets the cursor position, if it's shown.
+	l.tui.cursorpos = nil
 
-	return newpane(rows, x, y)
+	return l.place(*l.config, 0, 0, width, height)
 }
 
-func (l *Layout) mainPane() pane {
-	screen := l.pane(paneScreen)
+func (l *Layout) place(node pkg.LayoutConfig, x, y, width, height int) []pane {
+	if node.Split == "" {
+		return []pane{l.pane(node.Pane, l.render(node.Pane, width, height), x, y)}
+	}
 
-	width := min(screen.width, mainMaxWidth)
-	height := screen.height
+	columns := node.Split == splitColumns
 
-	if screen.width > mainSideMinWidth && screen.width < mainSideMaxWidth {
-		remainWidth := screen.width - mainMinWidth - borderWidth
-		width = mainMinWidth + remainWidth - remainWidth/2
+	size := height
+	if columns {
+		size = width
 	}
 
-	x := screen.x
-	y := screen.y
+	children := l.visible(node, size)
 
-	return pane{nil, x, y, width, height}
-}
-
-func (l *Layout) mapPane() pane {
-	side := l.pane(paneSide)
+	sizes := make([]int, len(children))
+	rowses := make([]Rows, len(children))
+	avail := size - node.Gap*max(0, len(children)-1)
 
-	if side.width == 0 || side.height < mapMinHeight {
-		return pane{}
+	flexes := []int{}
+	for i, child := range children {
+		if !l.fits(child) {
+			flexes = append(flexes, i)
+		}
 	}
 
-	rows := l.tui.RenderMap(side.width, side.height)
+	// Fitted panes are rendered first, from the last to the first, never
+	// taking more than half of what remains if they have others to share
+	// the space with.
+	for i := len(children) - 1; i >= 0; i-- {
+		child := children[i]
+		if !l.fits(child) {
+			continue
+		}
+
+		limit := avail
+		if len(flexes) > 0 {
+			limit /= 2
+		}
+
+		if child.Max > 0 {
+			limit = min(limit, child.Max)
+		}
+
+		cwidth, cheight := width, limit
+		if columns {
+			cwidth, cheight = limit, height
+		}
+
+		rows := l.render(child.Pane, cwidth, cheight)
+
+		sizes[i] = len(rows)
+		if columns && len(rows) > 0 {
+			sizes[i] = len(rows[0])
+		}
+
+		if sizes[i] < child.Min {
+			sizes[i] = 0
+			continue
+		}
+
+		rowses[i] = rows
+		avail -= sizes[i]
+	}
 
-	x := side.x
-	y := side.y + borderWidth
+	share(children, flexes, sizes, avail)
 
-	return newpane(rows, x, y)
-}
+	panes := []pane{}
+	offset := 0
 
-func (l *Layout) outputPane() pane {
-	input := l.pane(paneInput)
-	main := l.pane(paneMain)
-	target := l.pane(paneTarget)
-	vitals := l.pane(paneVitals)
+	for i, child := range children {
+		if sizes[i] == 0 {
+			continue
+		}
 
-	bottomMargin := input.height + target.height + vitals.height
-	maxHeight := main.height - bottomMargin
+		cx, cy, cwidth, cheight := x, y+offset, width, sizes[i]
+		if columns {
+			cx, cy, cwidth, cheight = x+offset, y, sizes[i], height
+		}
 
-	rows := l.tui.RenderOutput(main.width, maxHeight)
+		if l.fits(child) {
+			panes = append(panes, l.pane(child.Pane, rowses[i], cx, cy))
+		} else {
+			panes = append(panes, l.place(child, cx, cy, cwidth, cheight)...)
+		}
 
-	x := main.x
-	y := main.y + main.height - len(rows) - bottomMargin
+		offset += sizes[i] + node.Gap
+	}
 
-	return newpane(rows, x, y)
+	return panes
 }
 
-func (l *Layout) sidePane() pane {
-	screen := l.pane(paneScreen)
-	main := l.pane(paneMain)
+// visible lists the children to show, leaving out empty panes and dropping
+// children from the end until all their minimum sizes fit. The first child is
+// always kept, however cramped.
+func (l *Layout) visible(node pkg.LayoutConfig, size int) []pkg.LayoutConfig {
+	children := []pkg.LayoutConfig{}
+
+	for _, child := range node.Children {
+		kind := l.kinds[child.Pane]
+		if child.Split == "" && kind.empty != nil && kind.empty() {
+			continue
+		}
 
-	if screen.width < mainSideMinWidth {
-		return pane{}
+		children = append(children, child)
 	}
 
-	width := screen.width - main.width - borderWidth
-	height := screen.height
+	for len(children) > 1 {
+		need := node.Gap * (len(children) - 1)
+		for _, child := range children {
+			need += child.Min
+		}
 
-	x := screen.x + screen.width - width
-	y := screen.y
+		if need <= size {
+			break
+		}
 
-	return pane{nil, x, y, width, height}
+		children = children[:len(children)-1]
+	}
+
+	return children
 }
 
-func (l *Layout) targetPane() pane {
-	main := l.pane(paneMain)
+// share distributes the available space evenly between the flexible children,
+// within their bounds.
+func share(children []pkg.LayoutConfig, flexes []int, sizes []int, avail int) {
+	for _, i := range flexes {
+		sizes[i] = children[i].Min
+		avail -= sizes[i]
+	}
 
-	if main.height < 6 {
-		return pane{}
+	// Hide children from the end, if fitted panes left too little room
+	// for everyone's minimum size.
+	for j := len(flexes) - 1; j > 0 && avail < 0; j-- {
+		avail += sizes[flexes[j]]
+		sizes[flexes[j]] = 0
+		flexes = flexes[:j]
 	}
 
-	rows := l.tui.RenderTarget(main.width)
+	if len(flexes) == 1 && avail < 0 {
+		sizes[flexes[0]] = max(0, sizes[flexes[0]]+avail)
+		return
+	}
 
-	x := main.x
-	y := main.y + main.height - len(rows)
+	for avail > 0 && len(flexes) > 0 {
+		portion, rest := avail/len(flexes), avail%len(flexes)
+		growing := []int{}
 
-	return newpane(rows, x, y)
+		for j, i := range flexes {
+			add := portion
+			if j < rest {
+				add++
+			}
+
+			if limit := children[i].Max; limit > 0 && sizes[i]+add >= limit {
+				add = limit - sizes[i]
+			} else {
+				growing = append(growing, i)
+			}
+
+			sizes[i] += add
+			avail -= add
+		}
+
+		flexes = growing
+	}
 }
 
-func (l *Layout) vitalsPane() pane {
-	input := l.pane(paneInput)
-	main := l.pane(paneMain)
-	target := l.pane(paneTarget)
+func (l *Layout) fits(node pkg.LayoutConfig) bool {
+	return node.Split == "" && l.kinds[node.Pane].fit
+}
 
-	if main.height < 5 {
-		return pane{}
+func (l *Layout) render(name string, width, height int) Rows {
+	if dimensions := [2]int{width, height}; l.dimensions[name] != dimensions {
+		l.tui.setCache(name, nil)
+		l.dimensions[name] = dimensions
 	}
 
-	rows := l.tui.RenderVitals(main.width)
+	kind := l.kinds[name]
+	if kind.render == nil || width <= 0 || height <= 0 {
+		return nil
+	}
 
-	x := main.x
-	y := main.y + main.height - len(rows) - input.height - target.height
+	return kind.render(width, height)
+}
+
+func (l *Layout) pane(name string, rows Rows, x, y int) pane {
+	if name == paneInput && len(rows) > 0 {
+		cursorpos := l.tui.input.cursorpos
+		l.tui.cursorpos = []int{x + cursorpos[0], y + cursorpos[1]}
+	}
 
 	return newpane(rows, x, y)
 }
@@ -0,0 +1,259 @@
+package tui
+
+import (
+	"testing"
+
+	"github.com/tobiassjosten/nogfx/pkg"
+	"github.com/tobiassjosten/nogfx/pkg/mock"
+	"github.com/tobiassjosten/nogfx/pkg/navigation"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLayoutPanes(t *testing.T) {
+	tcs := map[string]struct {
+		config    *pkg.LayoutConfig
+		character pkg.Character
+		target    *pkg.Target
+		comm      bool
+		width     int
+		height    int
+		panes     [][4]int
+	}{
+		"default narrow": {
+			width:  80,
+			height: 20,
+			panes: [][4]int{
+				{0, 0, 80, 19},
+				{0, 19, 80, 1},
+			},
+		},
+
+		// Fitted panes take at most half of what remains, so on short
+		// screens the output shrinks to make room for vitals and target.
+		"default short": {
+			character: pkg.Character{
+				Vitals: map[string]pkg.CharacterVital{
+					"health": {Value: 50, Max: 100},
+				},
+			},
+			target: &pkg.Target{Name: "rat", Health: 50},
+			width:  80,
+			height: 4,
+			panes: [][4]int{
+				{0, 0, 80, 1},
+				{0, 1, 80, 1},
+				{0, 2, 80, 1},
+				{0, 3, 80, 1},
+			},
+		},
+
+		"default wide": {
+			width:  140,
+			height: 20,
+			panes: [][4]int{
+				{0, 0, 102, 19},
+				{0, 19, 102, 1},
+				{104, 2, 36, 18},
+			},
+		},
+
+		"default wide with communications": {
+			comm:   true,
+			width:  140,
+			height: 20,
+			panes: [][4]int{
+				{0, 0, 102, 19},
+				{0, 19, 102, 1},
+				{104, 2, 36, 12},
+				{104, 14, 36, 6},
+			},
+		},
+
+		"default wide with afflictions": {
+			character: pkg.Character{
+				Afflictions: []pkg.CharacterAffliction{
+					{Name: "asthma", Cure: "eat kelp"},
+				},
+			},
+			width:  140,
+			height: 20,
+			panes: [][4]int{
+				{0, 0, 102, 19},
+				{0, 19, 102, 1},
+				{104, 2, 36, 1},
+				{104, 3, 36, 17},
+			},
+		},
+
+		"default widest": {
+			width:  200,
+			height: 20,
+			panes: [][4]int{
+				{0, 0, 120, 19},
+				{0, 19, 120, 1},
+				{122, 2, 78, 18},
+			},
+		},
+
+		"map to the left": {
+			config: &pkg.LayoutConfig{
+				Split: splitColumns,
+				Gap:   1,
+				Children: []pkg.LayoutConfig{
+					{Pane: paneMap, Min: 10, Max: 20},
+					{
+						Split: splitRows,
+						Children: []pkg.LayoutConfig{
+							{Pane: paneOutput},
+							{Pane: paneInput},
+						},
+					},
+				},
+			},
+			width:  50,
+			height: 10,
+			panes: [][4]int{
+				{0, 0, 20, 10},
+				{21, 0, 29, 9},
+				{21, 9, 29, 1},
+			},
+		},
+
+		"too cramped for the map": {
+			config: &pkg.LayoutConfig{
+				Split: splitColumns,
+				Children: []pkg.LayoutConfig{
+					{Pane: paneOutput},
+					{Pane: paneMap, Min: 10},
+				},
+			},
+			width:  9,
+			height: 10,
+			panes: [][4]int{
+				{0, 0, 9, 10},
+			},
+		},
+	}
+
+	for name, tc := range tcs {
+		t.Run(name, func(t *testing.T) {
+			tui := NewTUI(&mock.ScreenMock{
+				HideCursorFunc:     func() {},
+				SetCursorStyleFunc: func(_ tcell.CursorStyle) {},
+				SetStyleFunc:       func(_ tcell.Style) {},
+				SizeFunc: func() (int, int) {
+					return tc.width, tc.height
+				},
+			})
+
+			tui.room = &navigation.Room{}
+			tui.character = tc.character
+			tui.target = tc.target
+
+			if tc.comm {
+				tui.comm.AppendRow(NewRowFromRunes([]rune("hello")))
+			}
+
+			if tc.config != nil {
+				tui.layout = newLayout(tui, tc.config)
+			}
+
+			panes := [][4]int{}
+			for _, p := range tui.layout.panes() {
+				if p.width > 0 && p.height > 0 {
+					panes = append(panes, [4]int{p.x, p.y, p.width, p.height})
+				}
+			}
+
+			assert.Equal(t, tc.panes, panes)
+		})
+	}
+}
+
+func TestSetLayout(t *testing.T) {
+	tcs := map[string]struct {
+		config *pkg.LayoutConfig
+		err    string
+	}{
+		"nil restores default": {},
+
+		"custom": {
+			config: &pkg.LayoutConfig{
+				Split:    splitRows,
+				Children: []pkg.LayoutConfig{{Pane: paneOutput}},
+			},
+		},
+
+		"negative size": {
+			config: &pkg.LayoutConfig{Pane: paneMap, Min: -1},
+			err:    "invalid layout: negative size for 'map'",
+		},
+
+		"unknown pane": {
+			config: &pkg.LayoutConfig{Pane: "asdf"},
+			err:    "invalid layout: unknown pane 'asdf'",
+		},
+
+		"pane with children": {
+			config: &pkg.LayoutConfig{
+				Pane:     paneMap,
+				Children: []pkg.LayoutConfig{{Pane: paneOutput}},
+			},
+			err: "invalid layout: pane 'map' can't have children",
+		},
+
+		"split with pane": {
+			config: &pkg.LayoutConfig{Split: splitRows, Pane: paneMap},
+			err:    "invalid layout: split 'rows' can't be pane 'map'",
+		},
+
+		"unknown split": {
+			config: &pkg.LayoutConfig{Split: "diagonal"},
+			err:    "invalid layout: unknown split 'diagonal'",
+		},
+
+		"nested error": {
+			config: &pkg.LayoutConfig{
+				Split:    splitColumns,
+				Children: []pkg.LayoutConfig{{Pane: "asdf"}},
+			},
+			err: "invalid layout: unknown pane 'asdf'",
+		},
+	}
+
+	for name, tc := range tcs {
+		t.Run(name, func(t *testing.T) {
+			tui := NewTUI(&mock.ScreenMock{
+				ClearFunc:          func() {},
+				HideCursorFunc:     func() {},
+				SetCursorStyleFunc: func(_ tcell.CursorStyle) {},
+				SetStyleFunc:       func(_ tcell.Style) {},
+				ShowFunc:           func() {},
+				SizeFunc: func() (int, int) {
+					return 0, 0
+				},
+			})
+			tui.layout = newLayout(tui, &pkg.LayoutConfig{Pane: paneMap})
+
+			err := tui.SetLayout(tc.config)
+
+			if tc.err != "" {
+				assert.EqualError(t, err, tc.err)
+				assert.Equal(t, &pkg.LayoutConfig{Pane: paneMap}, tui.layout.config)
+
+				return
+			}
+
+			assert.Nil(t, err)
+
+			expected := tc.config
+			if expected == nil {
+				expected = DefaultLayout()
+			}
+
+			assert.Equal(t, expected, tui.layout.config)
+		})
+	}
+}
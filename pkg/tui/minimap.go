@@ -1,22 +1,54 @@
 package tui
 
 import (
+	"strings"
+
 	"github.com/tobiassjosten/nogfx/pkg/navigation"
 
 	"github.com/gdamore/tcell/v2"
 )
 
+// Room details worth marking on the map, in order of precedence, and the
+// glyphs to mark them with. They're styled by "minimap.<detail>" in themes.
+var minimapDetails = []struct {
+	detail string
+	glyph  rune
+}{
+	{"bank", '$'},
+	{"shop", 'S'},
+	{"sewer", '~'},
+	{"subdivision", '#'},
+}
+
 // Minimap is a map rendition based on the given room.
 type Minimap struct {
 	room     *navigation.Room
+	theme    *Theme
 	rows     Rows
 	rendered map[int]struct{}
+
+	// The level being rendered, with rooms on levels directly above or
+	// below it ghosted where there's room for them.
+	z       int
+	ghosts  []maproom
+	ghosted map[int]struct{}
 }
 
 type maproom struct {
 	room *navigation.Room
 	x    int
 	y    int
+	z    int
+}
+
+// level determines which level a room is on, using its z-coordinate when it's
+// known and otherwise the level we assume from how it was reached.
+func level(room *navigation.Room, assumed int) int {
+	if room.Z != nil {
+		return *room.Z
+	}
+
+	return assumed
 }
 
 // RenderMap renders a map from the current room.
@@ -25,7 +57,7 @@ func (tui *TUI) RenderMap(width, height int) Rows {
 		return rows
 	}
 
-	rows := RenderMap(tui.room, width, height)
+	rows := RenderMap(tui.room, width, height, tui.theme)
 
 	tui.setCache(paneMap, rows)
 
@@ -33,17 +65,25 @@ func (tui *TUI) RenderMap(width, height int) Rows {
 }
 
 // RenderMap renders cascading layers of adjacent rooms, based on the given.
-func RenderMap(room *navigation.Room, width, height int) Rows {
+func RenderMap(room *navigation.Room, width, height int, theme *Theme) Rows {
 	if room == nil || width == 0 || height == 0 {
 		return Rows{}
 	}
 
-	mmap := Minimap{room, NewRows(width, height), map[int]struct{}{}}
+	mmap := &Minimap{
+		room:     room,
+		theme:    theme,
+		rows:     NewRows(width, height),
+		rendered: map[int]struct{}{},
+		z:        level(room, 0),
+		ghosted:  map[int]struct{}{},
+	}
 
 	rooms := []maproom{{
 		room: room,
 		x:    len(mmap.rows[0]) / 2,
 		y:    len(mmap.rows) / 2,
+		z:    mmap.z,
 	}}
 
 	for len(rooms) > 0 {
@@ -51,10 +91,117 @@ func RenderMap(room *navigation.Room, width, height int) Rows {
 		rooms = append(rooms[1:], mmap.render(q.room, q.x, q.y)...)
 	}
 
+	// Rooms of the adjacent levels fill in the gaps of the current one.
+	for len(mmap.ghosts) > 0 {
+		q := mmap.ghosts[0]
+		mmap.ghosts = append(mmap.ghosts[1:], mmap.renderGhost(q)...)
+	}
+
 	return mmap.rows
 }
 
-func (mmap Minimap) render(room *navigation.Room, x, y int) []maproom {
+// renderGhost renders a room of an adjacent level, if its space isn't taken by
+// the current level, and returns the rooms next to it on the same level.
+func (mmap *Minimap) renderGhost(q maproom) []maproom {
+	if q.x < 2 || q.y < 1 || q.y > len(mmap.rows)-2 || q.x > len(mmap.rows[0])-3 {
+		return nil
+	}
+
+	if q.room.ID != 0 {
+		if _, done := mmap.rendered[q.room.ID]; done {
+			return nil
+		}
+
+		if _, done := mmap.ghosted[q.room.ID]; done {
+			return nil
+		}
+	}
+
+	mmap.ghosted[q.room.ID] = struct{}{}
+
+	free := true
+	for x := q.x - 1; x <= q.x+1; x++ {
+		free = free && mmap.rows[q.y][x].Content == ' '
+	}
+
+	if free {
+		style := mmap.theme.Style("minimap.ghost")
+		mmap.rows[q.y][q.x-1] = NewCell('[', style)
+		mmap.rows[q.y][q.x+1] = NewCell(']', style)
+	}
+
+	var adjacents []maproom
+
+	for direction, adjacent := range q.room.Exits {
+		if direction == "u" || direction == "d" || level(adjacent, q.z) != q.z {
+			continue
+		}
+
+		if q.room.Area != nil && adjacent.Area != nil && q.room.Area.ID != adjacent.Area.ID {
+			continue
+		}
+
+		diffx, diffy := q.room.Displacement(direction)
+		if diffx == 0 && diffy == 0 {
+			continue
+		}
+
+		adjacents = append(adjacents, maproom{
+			room: adjacent,
+			x:    q.x + 4*diffx,
+			y:    q.y + 2*diffy,
+			z:    q.z,
+		})
+	}
+
+	return adjacents
+}
+
+// bracketStyle finds the style of a room's brackets, with unknown rooms
+// dimmed and others colored by their environment, if the theme has a style
+// for it. Environment styles are named by their lowercase names, like
+// "minimap.environment.natural underground".
+func (mmap *Minimap) bracketStyle(room *navigation.Room) (tcell.Style, bool) {
+	if !room.Known {
+		return mmap.theme.Style("minimap.unknown"), true
+	}
+
+	if room.Environment == "" {
+		return tcell.Style{}, false
+	}
+
+	return mmap.theme.Lookup("minimap.environment." + strings.ToLower(room.Environment))
+}
+
+// ghost queues a room on a level directly above or below the current one, to
+// be rendered once the current level is done.
+func (mmap *Minimap) ghost(room *navigation.Room, direction string, adjacent *navigation.Room, x, y int) {
+	assumed := mmap.z
+
+	switch direction {
+	case "u":
+		assumed++
+
+	case "d":
+		assumed--
+	}
+
+	z := level(adjacent, assumed)
+	if z != mmap.z+1 && z != mmap.z-1 {
+		return
+	}
+
+	diffx, diffy := room.Displacement(direction)
+
+	mmap.ghosts = append(mmap.ghosts, maproom{
+		room: adjacent,
+		x:    x + 4*diffx,
+		y:    y + 2*diffy,
+		z:    z,
+	})
+}
+
+func (mmap *Minimap) render(room *navigation.Room, x, y int) []maproom {
 	// Make sure we have enough padding to render room and exits.
 	if x < 2 || y < 1 || y > len(mmap.rows)-2 || x > len(mmap.rows[0])-3 {
 		return nil
@@ -69,6 +216,18 @@ func (mmap Minimap) render(room *navigation.Room, x, y int) []maproom {
 	mmap.rows[y][x].Content = ' '
 	if room.HasPlayer {
 		mmap.rows[y][x].Content = '+'
+	} else if len(room.Tags) > 0 {
+		mmap.rows[y][x].Content = '*'
+		mmap.rows[y][x].Style = mmap.theme.Style("minimap.tagged")
+	} else {
+		for _, md := range minimapDetails {
+			if room.HasDetail(md.detail) {
+				mmap.rows[y][x].Content = md.glyph
+				mmap.rows[y][x].Style = mmap.theme.Style("minimap." + md.detail)
+
+				break
+			}
+		}
 	}
 
 	mmap.rendered[room.ID] = struct{}{}
@@ -76,17 +235,18 @@ func (mmap Minimap) render(room *navigation.Room, x, y int) []maproom {
 	mmap.rows[y][x-1].Content = '['
 	mmap.rows[y][x+1].Content = ']'
 
-	// @todo Consider which environments that are actually important for
-	// the map to visualise and color the brackets accordingly.
-
-	if !room.Known {
-		mmap.rows[y][x-1].Style = mmap.rows[y][x-1].Style.Foreground(tcell.Color237)
-		mmap.rows[y][x+1].Style = mmap.rows[y][x+1].Style.Foreground(tcell.Color237)
+	if style, ok := mmap.bracketStyle(room); ok {
+		mmap.rows[y][x-1].Style = style
+		mmap.rows[y][x+1].Style = style
 	}
 
 	var adjacents []maproom
 
 	for direction, adjacent := range room.Exits {
+		if direction == "u" || direction == "d" {
+			mmap.ghost(room, direction, adjacent, x, y)
+		}
+
 		switch direction {
 		case "u":
 			if room.HasPlayer {
@@ -95,13 +255,13 @@ func (mmap Minimap) render(room *navigation.Room, x, y int) []maproom {
 
 			if room.HasExit("d") {
 				mmap.rows[y][x].Content = '='
-				mmap.rows[y][x].Foreground(tcell.Color245)
+				mmap.rows[y][x].Style = mmap.theme.Style("minimap.vertical")
 
 				continue
 			}
 
 			mmap.rows[y][x].Content = '^'
-			mmap.rows[y][x].Foreground(tcell.Color245)
+			mmap.rows[y][x].Style = mmap.theme.Style("minimap.vertical")
 
 			continue
 
@@ -115,7 +275,7 @@ func (mmap Minimap) render(room *navigation.Room, x, y int) []maproom {
 			}
 
 			mmap.rows[y][x].Content = 'v'
-			mmap.rows[y][x].Foreground(tcell.Color245)
+			mmap.rows[y][x].Style = mmap.theme.Style("minimap.vertical")
 
 			continue
 
@@ -137,6 +297,13 @@ func (mmap Minimap) render(room *navigation.Room, x, y int) []maproom {
 			continue
 		}
 
+		// Exits like ramps can lead to other levels, which are only
+		// ever ghosted and don't show the path leading there.
+		if z := level(adjacent, mmap.z); z != mmap.z {
+			mmap.ghost(room, direction, adjacent, x, y)
+			continue
+		}
+
 		if room.Area == nil || adjacent.Area == nil || room.Area.ID == adjacent.Area.ID {
 			adjacents = append(adjacents, maproom{
 				room: adjacent,
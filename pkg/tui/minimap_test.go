@@ -370,6 +370,100 @@ func TestRenderMap(t *testing.T) {
 			},
 		},
 
+		"other levels ghosted": {
+			room: &navigation.Room{
+				ID:        1,
+				Z:         gox.NewInt(1),
+				HasPlayer: true,
+				Exits: map[string]*navigation.Room{
+					"u": {
+						ID: 2,
+						Z:  gox.NewInt(2),
+						Exits: map[string]*navigation.Room{
+							"n": {ID: 3, Z: gox.NewInt(2)},
+						},
+					},
+					"e": {ID: 4, Z: gox.NewInt(0)},
+					"w": {ID: 5, Z: gox.NewInt(1)},
+					"s": {ID: 6, Z: gox.NewInt(3)},
+				},
+			},
+			width:  13,
+			height: 7,
+			visual: []string{
+				`             `,
+				`     [ ]     `,
+				`             `,
+				` [ ]-[+] [ ] `,
+				`             `,
+				`             `,
+				`             `,
+			},
+		},
+
+		"other levels assumed": {
+			room: &navigation.Room{
+				ID:        1,
+				HasPlayer: true,
+				Exits: map[string]*navigation.Room{
+					"d": {
+						ID: 2,
+						Exits: map[string]*navigation.Room{
+							"s": {ID: 3},
+						},
+					},
+				},
+			},
+			width:  13,
+			height: 7,
+			visual: []string{
+				`             `,
+				`             `,
+				`             `,
+				`     [+]     `,
+				`             `,
+				`     [ ]     `,
+				`             `,
+			},
+		},
+
+		"room details": {
+			room: &navigation.Room{
+				ID:        1,
+				HasPlayer: true,
+				Details:   []string{"shop"},
+				Exits: map[string]*navigation.Room{
+					"n": {ID: 2, Details: []string{"shop", "bank"}},
+					"e": {ID: 3, Details: []string{"shop", "indoors"}},
+					"s": {ID: 4, Details: []string{"sewer"}},
+					"sw": {
+						ID:      8,
+						Details: []string{"bank"},
+						Tags:    []string{"gate"},
+					},
+					"w": {ID: 5, Details: []string{"subdivision"}},
+					"nw": {
+						ID:      6,
+						Details: []string{"bank"},
+						Exits: map[string]*navigation.Room{
+							"u": {ID: 7},
+						},
+					},
+				},
+			},
+			width:  13,
+			height: 7,
+			visual: []string{
+				`             `,
+				` [^] [$]     `,
+				`    \ |      `,
+				` [#]-[+]-[S] `,
+				`    / |      `,
+				` [*] [~]     `,
+				`             `,
+			},
+		},
+
 		"exits outwards only": {
 			room: &navigation.Room{
 				ID: 1,
@@ -400,7 +494,7 @@ func TestRenderMap(t *testing.T) {
 
 	for name, tc := range tcs {
 		t.Run(name, func(t *testing.T) {
-			rows := RenderMap(tc.room, tc.width, tc.height)
+			rows := RenderMap(tc.room, tc.width, tc.height, DefaultTheme())
 			if !assert.Equal(t,
 				strings.Join(tc.visual, "\n"),
 				strings.Join(rows.Strings(), "\n"),
@@ -413,3 +507,33 @@ func TestRenderMap(t *testing.T) {
 		})
 	}
 }
+
+func TestRenderMapStyles(t *testing.T) {
+	theme, err := ParseTheme([]byte(`{"styles": {
+		"minimap.environment.urban": {"fg": "red"},
+		"minimap.shop": {"fg": "blue"}
+	}}`))
+	assert.Nil(t, err)
+
+	room := &navigation.Room{
+		ID:          1,
+		Known:       true,
+		Environment: "Urban",
+		Details:     []string{"shop"},
+		Exits: map[string]*navigation.Room{
+			"e": {ID: 2, Known: true, Environment: "Road"},
+			"w": {ID: 3, Environment: "Urban"},
+		},
+	}
+
+	rows := RenderMap(room, 13, 3, theme)
+
+	red := tcell.StyleDefault.Foreground(tcell.ColorRed)
+	blue := tcell.StyleDefault.Foreground(tcell.ColorBlue)
+
+	assert.Equal(t, red, rows[1][5].Style, "environment")
+	assert.Equal(t, blue, rows[1][6].Style, "detail")
+	assert.Equal(t, red, rows[1][7].Style, "environment")
+	assert.Equal(t, tcell.Style{}, rows[1][9].Style, "unstyled environment")
+	assert.Equal(t, theme.Style("minimap.unknown"), rows[1][1].Style, "unknown")
+}
@@ -0,0 +1,86 @@
+package tui
+
+import (
+	"strings"
+
+	"github.com/tobiassjosten/nogfx/pkg"
+)
+
+// SetOccupants updates who and what is in the current room and causes a
+// repaint.
+func (tui *TUI) SetOccupants(occupants pkg.Occupants) {
+	tui.occupants = occupants
+	tui.setCache(paneOccupants, nil)
+	tui.Draw()
+}
+
+// RenderOccupants renders players, denizens and items in the current room,
+// with those of special interest marked.
+func (tui *TUI) RenderOccupants(width, height int) Rows {
+	if rows, ok := tui.getCache(paneOccupants); ok {
+		return rows
+	}
+
+	rows := Rows{}
+
+	groups := []struct {
+		name      string
+		occupants []pkg.Occupant
+	}{
+		{"Players", tui.occupants.Players},
+		{"Denizens", tui.occupants.Denizens},
+		{"Items", tui.occupants.Items},
+	}
+
+	for _, group := range groups {
+		if len(group.occupants) == 0 {
+			continue
+		}
+
+		rows = append(rows, NewRowFromRunes(
+			[]rune(group.name), tui.theme.Style("occupants.header"),
+		))
+
+		for _, occupant := range group.occupants {
+			rows = append(rows, tui.occupantRow(occupant))
+		}
+	}
+
+	for i, row := range rows {
+		if len(row) > width {
+			row = row[:width]
+		}
+
+		rows[i] = row.Pad(width, NewCell(' '))
+	}
+
+	if len(rows) > height {
+		rows = rows[:height]
+	}
+
+	tui.setCache(paneOccupants, rows)
+
+	return rows
+}
+
+func (tui *TUI) occupantRow(occupant pkg.Occupant) Row {
+	style := tui.theme.Style("occupants")
+	marker := ' '
+
+	if occupant.Marked {
+		style = tui.theme.Style("occupants.marked")
+		marker = '*'
+	}
+
+	row := NewRowFromRunes([]rune{marker}, style)
+	row = row.append(NewRowFromRunes([]rune(occupant.Name), style)...)
+
+	if len(occupant.Details) > 0 {
+		details := " (" + strings.Join(occupant.Details, ", ") + ")"
+		row = row.append(NewRowFromRunes(
+			[]rune(details), tui.theme.Style("occupants.details"),
+		)...)
+	}
+
+	return row
+}
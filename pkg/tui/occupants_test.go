@@ -0,0 +1,98 @@
+package tui
+
+import (
+	"testing"
+
+	"github.com/tobiassjosten/nogfx/pkg"
+	"github.com/tobiassjosten/nogfx/pkg/mock"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRenderOccupants(t *testing.T) {
+	occupants := pkg.Occupants{
+		Players: []pkg.Occupant{
+			{Name: "Jane", Marked: true},
+			{Name: "Bob"},
+		},
+		Denizens: []pkg.Occupant{
+			{Name: "a manticore", Marked: true},
+		},
+		Items: []pkg.Occupant{
+			{Name: "a chest", Details: []string{"container"}},
+		},
+	}
+
+	tcs := map[string]struct {
+		occupants pkg.Occupants
+		width     int
+		height    int
+		rows      []string
+	}{
+		"nothing": {
+			width:  10,
+			height: 3,
+			rows:   nil,
+		},
+
+		"everything": {
+			occupants: occupants,
+			width:     24,
+			height:    10,
+			rows: []string{
+				"Players                 ",
+				"*Jane                   ",
+				" Bob                    ",
+				"Denizens                ",
+				"*a manticore            ",
+				"Items                   ",
+				" a chest (container)    ",
+			},
+		},
+
+		"cramped": {
+			occupants: occupants,
+			width:     8,
+			height:    3,
+			rows: []string{
+				"Players ",
+				"*Jane   ",
+				" Bob    ",
+			},
+		},
+	}
+
+	for name, tc := range tcs {
+		t.Run(name, func(t *testing.T) {
+			ui := NewTUI(&mock.ScreenMock{
+				HideCursorFunc:     func() {},
+				SetCursorStyleFunc: func(_ tcell.CursorStyle) {},
+				SetStyleFunc:       func(_ tcell.Style) {},
+			})
+
+			ui.SetOccupants(tc.occupants)
+
+			rows := ui.RenderOccupants(tc.width, tc.height)
+			assert.Equal(t, tc.rows, rows.Strings())
+		})
+	}
+}
+
+func TestRenderOccupantsStyles(t *testing.T) {
+	ui := NewTUI(&mock.ScreenMock{
+		HideCursorFunc:     func() {},
+		SetCursorStyleFunc: func(_ tcell.CursorStyle) {},
+		SetStyleFunc:       func(_ tcell.Style) {},
+	})
+
+	ui.SetOccupants(pkg.Occupants{
+		Denizens: []pkg.Occupant{{Name: "a", Marked: true}, {Name: "b"}},
+	})
+
+	rows := ui.RenderOccupants(2, 3)
+
+	assert.Equal(t, darkTheme.Style("occupants.header"), rows[0][0].Style)
+	assert.Equal(t, darkTheme.Style("occupants.marked"), rows[1][1].Style)
+	assert.Equal(t, darkTheme.Style("occupants"), rows[2][1].Style)
+}
@@ -26,6 +26,11 @@ func (output *Output) Append(data []byte) {
 	row, style := NewRowFromBytes(data, output.style)
 	output.style = style
 
+	output.AppendRow(row)
+}
+
+// AppendRow adds a new, already styled, paragraph to the Output.
+func (output *Output) AppendRow(row Row) {
 	output.buffer = output.buffer.prepend(row)
 
 	if output.offset > 0 && output.pwidth > 0 {
@@ -175,7 +175,7 @@ func TestRenderOutput(t *testing.T) {
 func TestOutputAppend(t *testing.T) {
 	redStyle := (tcell.Style{}).
 		Foreground(tcell.ColorGreen).
-		Background(tcell.ColorBlue)
+		Background(tcell.ColorNavy)
 
 	tcs := map[string]struct {
 		datas  [][]byte
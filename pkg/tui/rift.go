@@ -0,0 +1,60 @@
+package tui
+
+import (
+	"fmt"
+
+	"github.com/tobiassjosten/nogfx/pkg"
+)
+
+// SetRift updates what's in the rift and causes a repaint.
+func (tui *TUI) SetRift(items []pkg.RiftItem) {
+	tui.rift = items
+	tui.setCache(paneRift, nil)
+	tui.Draw()
+}
+
+// RenderRift renders the amounts of items in the rift, flowing over as many
+// rows as they need, with those running low highlighted.
+func (tui *TUI) RenderRift(width, height int) Rows {
+	if rows, ok := tui.getCache(paneRift); ok {
+		return rows
+	}
+
+	rows := Rows{}
+	row := Row{}
+
+	for _, item := range tui.rift {
+		style := tui.theme.Style("rift")
+		if item.Low {
+			style = tui.theme.Style("rift.low")
+		}
+
+		entry := NewRowFromRunes([]rune(fmt.Sprintf("%s %d", item.Name, item.Amount)), style)
+		if len(entry) > width {
+			entry = entry[:width]
+		}
+
+		if len(row) > 0 && len(row)+2+len(entry) > width {
+			rows = append(rows, row.Pad(width, NewCell(' ')))
+			row = Row{}
+		}
+
+		if len(row) > 0 {
+			row = row.append(NewCell(' '), NewCell(' '))
+		}
+
+		row = row.append(entry...)
+	}
+
+	if len(row) > 0 {
+		rows = append(rows, row.Pad(width, NewCell(' ')))
+	}
+
+	if len(rows) > height {
+		rows = rows[:height]
+	}
+
+	tui.setCache(paneRift, rows)
+
+	return rows
+}
@@ -0,0 +1,91 @@
+package tui
+
+import (
+	"testing"
+
+	"github.com/tobiassjosten/nogfx/pkg"
+	"github.com/tobiassjosten/nogfx/pkg/mock"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRenderRift(t *testing.T) {
+	items := []pkg.RiftItem{
+		{Name: "kelp", Amount: 120},
+		{Name: "aurum", Amount: 3, Low: true},
+		{Name: "ginseng", Amount: 45},
+	}
+
+	tcs := map[string]struct {
+		items  []pkg.RiftItem
+		width  int
+		height int
+		rows   []string
+	}{
+		"nothing": {
+			width:  10,
+			height: 3,
+			rows:   nil,
+		},
+
+		"one row": {
+			items:  items,
+			width:  32,
+			height: 3,
+			rows: []string{
+				"kelp 120  aurum 3  ginseng 45   ",
+			},
+		},
+
+		"flowing": {
+			items:  items,
+			width:  18,
+			height: 3,
+			rows: []string{
+				"kelp 120  aurum 3 ",
+				"ginseng 45        ",
+			},
+		},
+
+		"cramped": {
+			items:  items,
+			width:  8,
+			height: 2,
+			rows: []string{
+				"kelp 120",
+				"aurum 3 ",
+			},
+		},
+	}
+
+	for name, tc := range tcs {
+		t.Run(name, func(t *testing.T) {
+			ui := NewTUI(&mock.ScreenMock{
+				HideCursorFunc:     func() {},
+				SetCursorStyleFunc: func(_ tcell.CursorStyle) {},
+				SetStyleFunc:       func(_ tcell.Style) {},
+			})
+
+			ui.SetRift(tc.items)
+
+			rows := ui.RenderRift(tc.width, tc.height)
+			assert.Equal(t, tc.rows, rows.Strings())
+		})
+	}
+}
+
+func TestRenderRiftStyles(t *testing.T) {
+	ui := NewTUI(&mock.ScreenMock{
+		HideCursorFunc:     func() {},
+		SetCursorStyleFunc: func(_ tcell.CursorStyle) {},
+		SetStyleFunc:       func(_ tcell.Style) {},
+	})
+
+	ui.SetRift([]pkg.RiftItem{{Name: "a", Amount: 1}, {Name: "b", Amount: 0, Low: true}})
+
+	rows := ui.RenderRift(10, 1)
+
+	assert.Equal(t, darkTheme.Style("rift"), rows[0][0].Style)
+	assert.Equal(t, darkTheme.Style("rift.low"), rows[0][5].Style)
+}
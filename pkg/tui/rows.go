@@ -2,6 +2,9 @@ package tui
 
 import (
 	"strconv"
+	"strings"
+
+	"github.com/tobiassjosten/nogfx/pkg/ansi"
 
 	"github.com/gdamore/tcell/v2"
 	"github.com/mattn/go-runewidth"
@@ -66,7 +69,8 @@ func NewRowFromRunes(rs []rune, styles ...tcell.Style) Row {
 }
 
 // NewRowFromBytes traveses a raw text with ANSI control sequences and
-// transforms that into styled Cells.
+// transforms that into styled Cells. SGR sequences style the cells and OSC 8
+// hyperlinks are kept with them, while other sequences are dropped.
 func NewRowFromBytes(bs []byte, styles ...tcell.Style) (Row, tcell.Style) {
 	row := Row{}
 
@@ -76,51 +80,65 @@ func NewRowFromBytes(bs []byte, styles ...tcell.Style) (Row, tcell.Style) {
 
 	style := styles[0]
 
-	escaped := false
-	parsing := false
-	ansi := []rune{}
-
-	for _, r := range string(bs) {
-		if r == '\033' {
-			escaped = true
-			continue
-		}
-
-		if escaped {
-			if r == '[' {
-				parsing = true
-			} else {
-				row = row.append(NewCell('^', style), NewCell(r, style))
+	for _, token := range ansi.Parse(bs) {
+		switch token.Kind {
+		case ansi.Text:
+			for _, r := range string(token.Data) {
+				row = row.append(NewCell(r, style))
 			}
 
-			escaped = false
+		case ansi.CSI:
+			if token.Final == 'm' {
+				style = ApplyANSI(style, sgrParams(string(token.Data))...)
+			}
 
-			continue
+		case ansi.OSC:
+			if url, id, ok := ansi.Hyperlink(token.Data); ok {
+				style = hyperlink(style, url, id)
+			}
 		}
+	}
 
-		if parsing {
-			if r == ';' || r == 'm' {
-				ansii, err := strconv.Atoi(string(ansi))
-				if err == nil {
-					style = ApplyANSI(style, ansii)
-				}
+	return row, style
+}
 
-				ansi = []rune{}
+// hyperlink sets or, with an empty URL, removes the hyperlink of a style.
+func hyperlink(style tcell.Style, url, id string) tcell.Style {
+	// The link ID can't be unset, so we build a new style without it.
+	fg, bg, attrs := style.Decompose()
+	style = tcell.StyleDefault.
+		Foreground(fg).
+		Background(bg).
+		Attributes(attrs).
+		Url(url)
+
+	if id != "" {
+		style = style.UrlId(id)
+	}
 
-				if r == 'm' {
-					parsing = false
-				}
-			} else {
-				ansi = append(ansi, r)
-			}
+	return style
+}
 
+// sgrParams splits the parameters of an SGR sequence, where empty parameters
+// default to zero (i.e. reset). Any invalid parameter voids the whole sequence.
+func sgrParams(params string) []int {
+	codes := []int{}
+
+	for _, param := range strings.Split(params, ";") {
+		if param == "" {
+			codes = append(codes, 0)
 			continue
 		}
 
-		row = row.append(NewCell(r, style))
+		code, err := strconv.Atoi(param)
+		if err != nil {
+			return nil
+		}
+
+		codes = append(codes, code)
 	}
 
-	return row, style
+	return codes
 }
 
 // String converts the row to a string.
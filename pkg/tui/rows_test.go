@@ -28,11 +28,11 @@ func rowsToStrings(rows tui.Rows) (strs []string) {
 func TestNewRow(t *testing.T) {
 	baseStyle := tcell.Style{}
 	redStyle := baseStyle.
-		Foreground(tcell.ColorRed).
-		Background(tcell.ColorYellow)
+		Foreground(tcell.ColorMaroon).
+		Background(tcell.ColorOlive)
 	greenStyle := baseStyle.
 		Foreground(tcell.ColorGreen).
-		Background(tcell.ColorBlue)
+		Background(tcell.ColorNavy)
 
 	tcs := map[string]struct {
 		width    int
@@ -92,8 +92,6 @@ func TestNewRow(t *testing.T) {
 			bs:      []byte("\033{32ma"),
 			stylein: &greenStyle,
 			row: tui.Row{
-				tui.NewCell('^', greenStyle),
-				tui.NewCell('{', greenStyle),
 				tui.NewCell('3', greenStyle),
 				tui.NewCell('2', greenStyle),
 				tui.NewCell('m', greenStyle),
@@ -141,6 +139,103 @@ func TestNewRow(t *testing.T) {
 	}
 }
 
+func TestNewRowFromBytes(t *testing.T) {
+	tcs := map[string]struct {
+		bs    string
+		row   string
+		style tcell.Style
+	}{
+		"plain": {
+			bs:  "a",
+			row: "a",
+		},
+
+		"reset": {
+			bs:  "\033[31ma\033[mb",
+			row: "ab",
+		},
+
+		"empty parameter reset": {
+			bs:    "\033[31;;4ma",
+			row:   "a",
+			style: (tcell.Style{}).Underline(true),
+		},
+
+		"bold and color": {
+			bs:  "\033[1;32ma",
+			row: "a",
+			style: (tcell.Style{}).
+				Foreground(tcell.ColorGreen).
+				Bold(true),
+		},
+
+		"bright color": {
+			bs:    "\033[92ma",
+			row:   "a",
+			style: (tcell.Style{}).Foreground(tcell.ColorLime),
+		},
+
+		"8-bit colors": {
+			bs:  "\033[38;5;208;48;5;235ma",
+			row: "a",
+			style: (tcell.Style{}).
+				Foreground(tcell.PaletteColor(208)).
+				Background(tcell.PaletteColor(235)),
+		},
+
+		"24-bit colors": {
+			bs:  "\033[38;2;255;128;0;48;2;0;0;64ma",
+			row: "a",
+			style: (tcell.Style{}).
+				Foreground(tcell.NewRGBColor(255, 128, 0)).
+				Background(tcell.NewRGBColor(0, 0, 64)),
+		},
+
+		"split sequences": {
+			bs:  "\033[38;5;1ma\033[4mb",
+			row: "ab",
+			style: (tcell.Style{}).
+				Foreground(tcell.PaletteColor(1)).
+				Underline(true),
+		},
+
+		"invalid parameter": {
+			bs:  "\033[3?;1ma",
+			row: "a",
+		},
+
+		"non-sgr sequence": {
+			bs:  "\033[2Ja\033[1;1Hb",
+			row: "ab",
+		},
+
+		"window title": {
+			bs:  "\033]0;Achaea\007a",
+			row: "a",
+		},
+
+		"hyperlink": {
+			bs:    "\033]8;id=1;https://achaea.com\033\\a",
+			row:   "a",
+			style: (tcell.Style{}).Url("https://achaea.com").UrlId("1"),
+		},
+
+		"hyperlink ended": {
+			bs:  "\033]8;;https://achaea.com\007a\033]8;;\007b",
+			row: "ab",
+		},
+	}
+
+	for name, tc := range tcs {
+		t.Run(name, func(t *testing.T) {
+			row, style := tui.NewRowFromBytes([]byte(tc.bs))
+
+			assert.Equal(t, tc.row, row.String())
+			assert.Equal(t, tc.style, style)
+		})
+	}
+}
+
 func TestWrap(t *testing.T) {
 	tcs := map[string]struct {
 		line    string
@@ -0,0 +1,44 @@
+package tui
+
+// ToggleSheet shows or hides the character sheet.
+func (tui *TUI) ToggleSheet() bool {
+	tui.sheet = !tui.sheet
+	tui.setCache(paneSheet, nil)
+
+	return true
+}
+
+// RenderSheet renders details about the character, like its class and gold,
+// with their names lined up.
+func (tui *TUI) RenderSheet(width, height int) Rows {
+	if rows, ok := tui.getCache(paneSheet); ok {
+		return rows
+	}
+
+	namewidth := 0
+	for _, detail := range tui.character.Sheet {
+		namewidth = max(namewidth, len([]rune(detail.Name)))
+	}
+
+	rows := Rows{}
+
+	for _, detail := range tui.character.Sheet {
+		row := NewRowFromRunes([]rune(detail.Name+":"), tui.theme.Style("sheet.name"))
+		row = row.Pad(namewidth+2, NewCell(' '))
+		row = row.append(NewRowFromRunes([]rune(detail.Value), tui.theme.Style("sheet"))...)
+
+		if len(row) > width {
+			row = row[:width]
+		}
+
+		rows = append(rows, row.Pad(width, NewCell(' ')))
+	}
+
+	if len(rows) > height {
+		rows = rows[:height]
+	}
+
+	tui.setCache(paneSheet, rows)
+
+	return rows
+}
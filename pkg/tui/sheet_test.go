@@ -0,0 +1,89 @@
+package tui
+
+import (
+	"testing"
+
+	"github.com/tobiassjosten/nogfx/pkg"
+	"github.com/tobiassjosten/nogfx/pkg/mock"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRenderSheet(t *testing.T) {
+	sheet := []pkg.CharacterDetail{
+		{Name: "Name", Value: "Mason Durak"},
+		{Name: "Class", Value: "Monk"},
+		{Name: "Gold", Value: "150"},
+	}
+
+	tcs := map[string]struct {
+		sheet  []pkg.CharacterDetail
+		width  int
+		height int
+		rows   []string
+	}{
+		"nothing": {
+			width:  10,
+			height: 3,
+			rows:   nil,
+		},
+
+		"everything": {
+			sheet:  sheet,
+			width:  20,
+			height: 5,
+			rows: []string{
+				"Name:  Mason Durak  ",
+				"Class: Monk         ",
+				"Gold:  150          ",
+			},
+		},
+
+		"cramped": {
+			sheet:  sheet,
+			width:  10,
+			height: 2,
+			rows: []string{
+				"Name:  Mas",
+				"Class: Mon",
+			},
+		},
+	}
+
+	for name, tc := range tcs {
+		t.Run(name, func(t *testing.T) {
+			ui := NewTUI(&mock.ScreenMock{
+				HideCursorFunc:     func() {},
+				SetCursorStyleFunc: func(_ tcell.CursorStyle) {},
+				SetStyleFunc:       func(_ tcell.Style) {},
+			})
+
+			ui.SetCharacter(pkg.Character{Sheet: tc.sheet})
+
+			rows := ui.RenderSheet(tc.width, tc.height)
+			assert.Equal(t, tc.rows, rows.Strings())
+		})
+	}
+}
+
+func TestSheetToggle(t *testing.T) {
+	ui := NewTUI(&mock.ScreenMock{
+		SetCursorStyleFunc: func(_ tcell.CursorStyle) {},
+		SetStyleFunc:       func(_ tcell.Style) {},
+	})
+
+	ui.SetCharacter(pkg.Character{Sheet: []pkg.CharacterDetail{
+		{Name: "Gold", Value: "150"},
+	}})
+
+	empty := ui.paneKinds()[paneSheet].empty
+
+	assert.True(t, empty())
+
+	assert.True(t, ui.HandleEvent(key(tcell.KeyF4)))
+	assert.False(t, empty())
+
+	assert.True(t, ui.HandleEvent(key(tcell.KeyF4)))
+	assert.True(t, empty())
+}
@@ -0,0 +1,219 @@
+package tui
+
+import (
+	"fmt"
+
+	"github.com/tobiassjosten/nogfx/pkg"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+// SkillBrowser is a full-screen listing of skill groups, with ranks and the
+// skills within them, for looking them up.
+type SkillBrowser struct {
+	group int
+
+	// The selected skill within the group, or -1 for the group itself.
+	skill int
+}
+
+const skillBrowserHelp = "up/down move, right skills, left groups, enter info, esc close"
+
+// SetSkills updates the skill groups and causes a repaint.
+func (tui *TUI) SetSkills(skillGroups []pkg.SkillGroup) {
+	tui.skills = skillGroups
+
+	if browser := tui.skillBrowser; browser != nil {
+		if browser.group >= len(tui.skills) {
+			browser.group = max(0, len(tui.skills)-1)
+			browser.skill = -1
+		}
+
+		if skills := tui.selectedSkills(); browser.skill >= len(skills) {
+			browser.skill = len(skills) - 1
+		}
+	}
+
+	tui.Draw()
+}
+
+// ToggleSkillBrowser shows or hides the skills browser.
+func (tui *TUI) ToggleSkillBrowser() bool {
+	tui.clear = true
+	tui.clearCache()
+
+	if tui.skillBrowser != nil {
+		tui.skillBrowser = nil
+		return true
+	}
+
+	tui.skillBrowser = &SkillBrowser{skill: -1}
+
+	return true
+}
+
+func (tui *TUI) selectedSkills() []pkg.Skill {
+	if tui.skillBrowser == nil || tui.skillBrowser.group >= len(tui.skills) {
+		return nil
+	}
+
+	return tui.skills[tui.skillBrowser.group].Skills
+}
+
+// handleSkillBrowserEvent reacts to keys while the skills browser is shown.
+func (tui *TUI) handleSkillBrowserEvent(ev *tcell.EventKey) bool {
+	browser := tui.skillBrowser
+	skills := tui.selectedSkills()
+
+	switch ev.Key() {
+	case tcell.KeyEsc:
+		return tui.ToggleSkillBrowser()
+
+	case tcell.KeyUp:
+		switch {
+		case browser.skill >= 0:
+			browser.skill--
+
+		case browser.group > 0:
+			browser.group--
+		}
+
+	case tcell.KeyDown:
+		switch {
+		case browser.skill >= 0:
+			browser.skill = min(browser.skill+1, len(skills)-1)
+
+		case browser.group < len(tui.skills)-1:
+			browser.group++
+		}
+
+	case tcell.KeyRight:
+		if browser.skill < 0 && len(skills) > 0 {
+			browser.skill = 0
+		}
+
+	case tcell.KeyLeft:
+		browser.skill = -1
+
+	case tcell.KeyEnter:
+		if browser.skill < 0 || skills[browser.skill].Info != "" {
+			return true
+		}
+
+		tui.inputs <- []byte(fmt.Sprintf(
+			"skill info %s %s",
+			tui.skills[browser.group].Name, skills[browser.skill].Name,
+		))
+	}
+
+	return true
+}
+
+// RenderSkillBrowser renders the skills browser, with the selected group
+// expanded and the information about the selected skill at the bottom.
+func (tui *TUI) RenderSkillBrowser(width, height int) Rows {
+	if tui.skillBrowser == nil || width == 0 || height < 3 {
+		return Rows{}
+	}
+
+	browser := tui.skillBrowser
+	blank := NewCell(' ')
+
+	info := Rows{}
+	if skills := tui.selectedSkills(); browser.skill >= 0 && skills[browser.skill].Info != "" {
+		info = NewRowFromRunes(
+			[]rune(skills[browser.skill].Info), tui.theme.Style("skills"),
+		).Wrap(width, blank)
+
+		if len(info) > (height-2)/2 {
+			info = info[:(height-2)/2]
+		}
+	}
+
+	lines, selected := tui.skillLines()
+
+	body := height - 2 - len(info)
+	if offset := selected - body + 1; offset > 0 {
+		lines = lines[offset:]
+	}
+
+	if len(lines) > body {
+		lines = lines[:body]
+	}
+
+	for i, line := range lines {
+		if len(line) > width {
+			line = line[:width]
+		}
+
+		lines[i] = line.Pad(width, blank)
+	}
+
+	lines = append(lines, NewRows(width, body-len(lines), blank)...)
+
+	rows := Rows{browserRow("Skills", width, tui.theme.Style("browser.header"))}
+	rows = append(rows, lines...)
+	rows = append(rows, info...)
+	rows = append(rows, browserRow(skillBrowserHelp, width, tui.theme.Style("browser.footer")))
+
+	return rows
+}
+
+// skillLines lists all groups, with the skills of the selected one beneath
+// it, and tells which line is selected.
+func (tui *TUI) skillLines() (Rows, int) {
+	browser := tui.skillBrowser
+
+	lines := Rows{}
+	selected := 0
+
+	namewidth := 0
+	for _, group := range tui.skills {
+		namewidth = max(namewidth, len(group.Name))
+	}
+
+	for i, group := range tui.skills {
+		text := fmt.Sprintf("%-*s  %s", namewidth, group.Name, group.RankString())
+		line := NewRowFromRunes([]rune(text), tui.theme.Style("skills.group"))
+
+		if i == browser.group && browser.skill < 0 {
+			line = NewRowFromRunes([]rune(text), tui.theme.Style("browser.selected"))
+			selected = len(lines)
+		}
+
+		lines = append(lines, line)
+
+		if i != browser.group {
+			continue
+		}
+
+		if group.Skills == nil {
+			lines = append(lines, NewRowFromRunes(
+				[]rune("  (loading)"), tui.theme.Style("skills.description"),
+			))
+
+			continue
+		}
+
+		for ii, skill := range group.Skills {
+			style := tui.theme.Style("skills")
+			if ii == browser.skill {
+				style = tui.theme.Style("browser.selected")
+				selected = len(lines)
+			}
+
+			line := NewRowFromRunes([]rune("  "+skill.Name), style)
+
+			if skill.Description != "" {
+				line = line.append(NewRowFromRunes(
+					[]rune(" - "+skill.Description),
+					tui.theme.Style("skills.description"),
+				)...)
+			}
+
+			lines = append(lines, line)
+		}
+	}
+
+	return lines, selected
+}
@@ -0,0 +1,142 @@
+package tui
+
+import (
+	"testing"
+
+	"github.com/tobiassjosten/nogfx/pkg"
+	"github.com/tobiassjosten/nogfx/pkg/mock"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func skillsTUI() *TUI {
+	ui := NewTUI(&mock.ScreenMock{
+		SetCursorStyleFunc: func(_ tcell.CursorStyle) {},
+		SetStyleFunc:       func(_ tcell.Style) {},
+	})
+
+	ui.SetSkills([]pkg.SkillGroup{
+		{Name: "Survival", Rank: "Adept", Progress: 45, Skills: []pkg.Skill{
+			{Name: "Swimming", Description: "Stay afloat."},
+			{Name: "Fitness", Info: "Cure asthma."},
+		}},
+		{Name: "Tattoos", Rank: "Inept", Progress: -1},
+	})
+
+	return ui
+}
+
+func TestSkillBrowserToggle(t *testing.T) {
+	ui := skillsTUI()
+
+	assert.True(t, ui.HandleEvent(key(tcell.KeyF3)))
+	require.NotNil(t, ui.skillBrowser)
+
+	// Keys go to the browser instead of the input while it's shown.
+	ui.HandleEvent(runes("x")[0])
+	assert.Empty(t, ui.input.buffer)
+
+	assert.True(t, ui.HandleEvent(key(tcell.KeyEsc)))
+	assert.Nil(t, ui.skillBrowser)
+}
+
+func TestSkillBrowserNavigation(t *testing.T) {
+	tcs := map[string]struct {
+		events []*tcell.EventKey
+		group  int
+		skill  int
+	}{
+		"next group": {
+			events: []*tcell.EventKey{key(tcell.KeyDown), key(tcell.KeyDown)},
+			group:  1,
+			skill:  -1,
+		},
+
+		"previous group": {
+			events: []*tcell.EventKey{key(tcell.KeyDown), key(tcell.KeyUp)},
+			group:  0,
+			skill:  -1,
+		},
+
+		"into skills": {
+			events: []*tcell.EventKey{key(tcell.KeyRight), key(tcell.KeyDown), key(tcell.KeyDown)},
+			group:  0,
+			skill:  1,
+		},
+
+		"back out of skills": {
+			events: []*tcell.EventKey{key(tcell.KeyRight), key(tcell.KeyDown), key(tcell.KeyLeft)},
+			group:  0,
+			skill:  -1,
+		},
+
+		"unlisted skills": {
+			events: []*tcell.EventKey{key(tcell.KeyDown), key(tcell.KeyRight)},
+			group:  1,
+			skill:  -1,
+		},
+	}
+
+	for name, tc := range tcs {
+		t.Run(name, func(t *testing.T) {
+			ui := skillsTUI()
+			ui.ToggleSkillBrowser()
+
+			for _, event := range tc.events {
+				assert.True(t, ui.HandleEvent(event))
+			}
+
+			require.NotNil(t, ui.skillBrowser)
+			assert.Equal(t, tc.group, ui.skillBrowser.group)
+			assert.Equal(t, tc.skill, ui.skillBrowser.skill)
+		})
+	}
+}
+
+func TestSkillBrowserInfo(t *testing.T) {
+	ui := skillsTUI()
+	ui.ToggleSkillBrowser()
+	ui.HandleEvent(key(tcell.KeyRight))
+
+	go ui.HandleEvent(key(tcell.KeyEnter))
+
+	assert.Equal(t, []byte("skill info Survival Swimming"), <-ui.inputs)
+}
+
+func TestRenderSkillBrowser(t *testing.T) {
+	ui := skillsTUI()
+	assert.Equal(t, Rows{}, ui.RenderSkillBrowser(30, 8))
+
+	ui.ToggleSkillBrowser()
+
+	rows := ui.RenderSkillBrowser(30, 8)
+	require.Len(t, rows, 8)
+
+	assert.Equal(t, []string{
+		"Skills                        ",
+		"Survival  Adept (45%)         ",
+		"  Swimming - Stay afloat.     ",
+		"  Fitness                     ",
+		"Tattoos   Inept               ",
+		"                              ",
+		"                              ",
+		string([]rune(skillBrowserHelp)[:30]),
+	}, rows.Strings())
+	assert.Equal(t, DefaultTheme().Style("browser.selected"), rows[1][0].Style)
+
+	ui.HandleEvent(key(tcell.KeyRight))
+	ui.HandleEvent(key(tcell.KeyDown))
+
+	rows = ui.RenderSkillBrowser(30, 8)
+	assert.Equal(t, "Cure asthma.                  ", rows[6].String())
+	assert.Equal(t, DefaultTheme().Style("browser.selected"), rows[3][2].Style)
+
+	ui.HandleEvent(key(tcell.KeyLeft))
+	ui.HandleEvent(key(tcell.KeyDown))
+
+	rows = ui.RenderSkillBrowser(30, 8)
+	assert.Equal(t, "Tattoos   Inept               ", rows[2].String())
+	assert.Equal(t, "  (loading)                   ", rows[3].String())
+}
@@ -0,0 +1,97 @@
+package tui
+
+import (
+	"fmt"
+	"path/filepath"
+	"time"
+
+	"github.com/tobiassjosten/nogfx/pkg"
+)
+
+// SetStatus updates the session's status and causes a repaint.
+func (tui *TUI) SetStatus(status pkg.Status) {
+	tui.status = status
+	tui.setCache(paneStatus, nil)
+	tui.Draw()
+}
+
+// RenderStatus renders a status bar with an overview of the session, its
+// connection and the current area.
+func (tui *TUI) RenderStatus(width int) Rows {
+	if rows, ok := tui.getCache(paneStatus); ok {
+		return rows
+	}
+
+	if width == 0 {
+		return Rows{}
+	}
+
+	status := tui.status
+	style := tui.theme.Style("status")
+
+	address := status.Address
+	if status.World != "" {
+		address += fmt.Sprintf(" (%s)", status.World)
+	}
+
+	state, stateStyle := "disconnected", tui.theme.Style("status.warning")
+	if status.Connected {
+		state, stateStyle = "connected", style
+	}
+
+	gmcp := "GMCP off"
+	if status.GMCP {
+		gmcp = "GMCP on"
+	}
+
+	segments := []Row{
+		NewRowFromRunes([]rune(address), style),
+		NewRowFromRunes([]rune(fmt.Sprintf(
+			"%s %s", state, formatDuration(status.Duration),
+		)), stateStyle),
+		NewRowFromRunes([]rune(gmcp), style),
+		NewRowFromRunes([]rune("MCCP "+status.MCCP), style),
+	}
+
+	if room := tui.room; room != nil && room.Area != nil && room.Area.Name != "" {
+		segments = append(segments, NewRowFromRunes([]rune(room.Area.Name), style))
+	}
+
+	if status.Logfile != "" {
+		segments = append(segments, NewRowFromRunes(
+			[]rune(filepath.Base(status.Logfile)), style,
+		))
+	}
+
+	row := Row{NewCell(' ', style)}
+
+	for i, segment := range segments {
+		if i > 0 {
+			row = row.append(NewRowFromRunes([]rune(" | "), style)...)
+		}
+
+		row = row.append(segment...)
+	}
+
+	if len(row) > width {
+		row = row[:width]
+	}
+
+	rows := Rows{row.Pad(width, NewCell(' ', style))}
+
+	tui.setCache(paneStatus, rows)
+
+	return rows
+}
+
+// formatDuration formats a duration in hours and minutes, like "1h05m".
+func formatDuration(duration time.Duration) string {
+	hours := int(duration.Hours())
+	minutes := int(duration.Minutes()) % 60
+
+	if hours == 0 {
+		return fmt.Sprintf("%dm", minutes)
+	}
+
+	return fmt.Sprintf("%dh%02dm", hours, minutes)
+}
@@ -0,0 +1,87 @@
+package tui
+
+import (
+	"testing"
+	"time"
+
+	"github.com/tobiassjosten/nogfx/pkg"
+	"github.com/tobiassjosten/nogfx/pkg/mock"
+	"github.com/tobiassjosten/nogfx/pkg/navigation"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRenderStatus(t *testing.T) {
+	tcs := map[string]struct {
+		status pkg.Status
+		room   *navigation.Room
+		width  int
+		rows   []string
+	}{
+		"connecting": {
+			status: pkg.Status{Address: "example.com:23", MCCP: pkg.MCCPOff},
+			width:  60,
+			rows: []string{
+				" example.com:23 | disconnected 0m | GMCP off | MCCP off     ",
+			},
+		},
+
+		"full": {
+			status: pkg.Status{
+				Address:   "achaea.com:23",
+				World:     "achaea",
+				Connected: true,
+				Duration:  65 * time.Minute,
+				GMCP:      true,
+				MCCP:      pkg.MCCPDeclined,
+				Logfile:   "/home/nogfx/logs/achaea.com-20221230-120000.log",
+			},
+			room: &navigation.Room{
+				Area: &navigation.Area{ID: 1, Name: "Mhaldor"},
+			},
+			width: 111,
+			rows: []string{
+				" achaea.com:23 (achaea) | connected 1h05m | GMCP on | MCCP declined | Mhaldor | achaea.com-20221230-120000.log ",
+			},
+		},
+
+		"cramped": {
+			status: pkg.Status{Address: "example.com:23", MCCP: pkg.MCCPOff},
+			width:  20,
+			rows:   []string{" example.com:23 | di"},
+		},
+	}
+
+	for name, tc := range tcs {
+		t.Run(name, func(t *testing.T) {
+			ui := NewTUI(&mock.ScreenMock{
+				HideCursorFunc:     func() {},
+				SetCursorStyleFunc: func(_ tcell.CursorStyle) {},
+				SetStyleFunc:       func(_ tcell.Style) {},
+			})
+
+			ui.SetRoom(tc.room)
+			ui.SetStatus(tc.status)
+
+			rows := ui.RenderStatus(tc.width)
+			assert.Equal(t, tc.rows, rows.Strings())
+		})
+	}
+}
+
+func TestRenderStatusStyles(t *testing.T) {
+	ui := NewTUI(&mock.ScreenMock{
+		HideCursorFunc:     func() {},
+		SetCursorStyleFunc: func(_ tcell.CursorStyle) {},
+		SetStyleFunc:       func(_ tcell.Style) {},
+	})
+
+	ui.SetStatus(pkg.Status{Address: "a", MCCP: pkg.MCCPOff})
+
+	rows := ui.RenderStatus(40)
+
+	assert.Equal(t, darkTheme.Style("status"), rows[0][0].Style)
+	assert.Equal(t, darkTheme.Style("status.warning"), rows[0][5].Style)
+	assert.Equal(t, darkTheme.Style("status"), rows[0][39].Style)
+}
@@ -19,7 +19,7 @@ func (tui *TUI) RenderTarget(width int) Rows {
 
 	row := RenderVital(
 		pkg.CharacterVital{Value: tui.target.Health, Max: 100},
-		width, vitalStyles["target"],
+		width, tui.theme.vitalStyles("target"),
 	)
 	lrow := len(row)
 
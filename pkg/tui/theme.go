@@ -0,0 +1,267 @@
+package tui
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"strconv"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+// Names of the built-in themes.
+const (
+	ThemeDark         = "dark"
+	ThemeLight        = "light"
+	ThemeHighContrast = "high-contrast"
+	ThemeColorblind   = "colorblind"
+)
+
+// ThemeNames lists the built-in themes, in the order they're cycled through.
+var ThemeNames = []string{
+	ThemeDark,
+	ThemeLight,
+	ThemeHighContrast,
+	ThemeColorblind,
+}
+
+// Theme is a set of named styles for the elements of the user interface.
+// Elements with variations are named hierarchically, like "vitals.health" and
+// "vitals.health.empty".
+type Theme struct {
+	Name   string
+	Styles map[string]tcell.Style
+}
+
+// Style looks up the named style, falling back on the dark theme and then no
+// style at all.
+func (theme *Theme) Style(name string) tcell.Style {
+	if style, ok := theme.Lookup(name); ok {
+		return style
+	}
+
+	return tcell.Style{}
+}
+
+// Lookup finds the named style and reports whether it exists, either in this
+// theme or in the dark theme it falls back on.
+func (theme *Theme) Lookup(name string) (tcell.Style, bool) {
+	if theme != nil {
+		if style, ok := theme.Styles[name]; ok {
+			return style, true
+		}
+	}
+
+	style, ok := darkTheme.Styles[name]
+
+	return style, ok
+}
+
+// vitalStyles looks up the full and empty styles of a vital bar, falling back
+// on the generic "vitals" styles.
+func (theme *Theme) vitalStyles(name string) []tcell.Style {
+	full, ok := theme.Lookup("vitals." + name)
+	if !ok {
+		full = theme.Style("vitals")
+	}
+
+	empty, ok := theme.Lookup("vitals." + name + ".empty")
+	if !ok {
+		empty = theme.Style("vitals.empty")
+	}
+
+	return []tcell.Style{full, empty}
+}
+
+// SetTheme changes the styles of the user interface, with nil restoring the
+// default, and causes a repaint.
+func (tui *TUI) SetTheme(theme *Theme) {
+	if theme == nil {
+		theme = DefaultTheme()
+	}
+
+	if builtin, ok := BuiltinTheme(theme.Name); !ok || builtin != theme {
+		tui.customTheme = theme
+	}
+
+	tui.theme = theme
+	tui.clearCache()
+	tui.Draw()
+}
+
+// themes lists the themes to cycle through, the built-in ones followed by any
+// custom theme that has been set.
+func (tui *TUI) themes() []*Theme {
+	themes := []*Theme{}
+
+	for _, name := range ThemeNames {
+		if theme, ok := BuiltinTheme(name); ok {
+			themes = append(themes, theme)
+		}
+	}
+
+	if tui.customTheme != nil {
+		themes = append(themes, tui.customTheme)
+	}
+
+	return themes
+}
+
+// DefaultTheme is the dark theme.
+func DefaultTheme() *Theme {
+	return darkTheme
+}
+
+// BuiltinTheme finds a built-in theme by its name.
+func BuiltinTheme(name string) (*Theme, bool) {
+	theme, ok := map[string]*Theme{
+		ThemeDark:         darkTheme,
+		ThemeLight:        lightTheme,
+		ThemeHighContrast: highContrastTheme,
+		ThemeColorblind:   colorblindTheme,
+	}[name]
+
+	return theme, ok
+}
+
+// LoadTheme loads a built-in theme by its name or else a theme file at the
+// given path. An empty name yields the default theme.
+func LoadTheme(name string) (*Theme, error) {
+	if name == "" {
+		return DefaultTheme(), nil
+	}
+
+	if theme, ok := BuiltinTheme(name); ok {
+		return theme, nil
+	}
+
+	data, err := os.ReadFile(name)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, fmt.Errorf("unknown theme '%s'", name)
+	} else if err != nil {
+		return nil, fmt.Errorf("failed reading theme: %w", err)
+	}
+
+	theme, err := ParseTheme(data)
+	if err != nil {
+		return nil, err
+	}
+
+	if theme.Name == "" {
+		theme.Name = name
+	}
+
+	return theme, nil
+}
+
+// themeFile is the format of theme files, where styles are laid on top of a
+// built-in base theme.
+type themeFile struct {
+	Name   string                `json:"name"`
+	Base   string                `json:"base"`
+	Styles map[string]themeStyle `json:"styles"`
+}
+
+// themeStyle is a style in a theme file. Colors are given by name (e.g.
+// "green"), by hex code (e.g. "#00ff00") or by palette number (e.g. "235").
+type themeStyle struct {
+	Fg    string   `json:"fg"`
+	Bg    string   `json:"bg"`
+	Attrs []string `json:"attrs"`
+}
+
+var themeAttrs = map[string]tcell.AttrMask{
+	"bold":          tcell.AttrBold,
+	"dim":           tcell.AttrDim,
+	"italic":        tcell.AttrItalic,
+	"underline":     tcell.AttrUnderline,
+	"blink":         tcell.AttrBlink,
+	"reverse":       tcell.AttrReverse,
+	"strikethrough": tcell.AttrStrikeThrough,
+}
+
+// ParseTheme parses the contents of a theme file.
+func ParseTheme(data []byte) (*Theme, error) {
+	file := themeFile{}
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("failed parsing theme: %w", err)
+	}
+
+	base := DefaultTheme()
+
+	if file.Base != "" {
+		var ok bool
+		if base, ok = BuiltinTheme(file.Base); !ok {
+			return nil, fmt.Errorf("unknown base theme '%s'", file.Base)
+		}
+	}
+
+	theme := &Theme{Name: file.Name, Styles: map[string]tcell.Style{}}
+	for name, style := range base.Styles {
+		theme.Styles[name] = style
+	}
+
+	for name, spec := range file.Styles {
+		style, err := spec.style()
+		if err != nil {
+			return nil, fmt.Errorf("invalid style '%s': %w", name, err)
+		}
+
+		theme.Styles[name] = style
+	}
+
+	return theme, nil
+}
+
+func (spec themeStyle) style() (tcell.Style, error) {
+	style := tcell.Style{}
+
+	if spec.Fg != "" {
+		color, err := themeColor(spec.Fg)
+		if err != nil {
+			return style, err
+		}
+
+		style = style.Foreground(color)
+	}
+
+	if spec.Bg != "" {
+		color, err := themeColor(spec.Bg)
+		if err != nil {
+			return style, err
+		}
+
+		style = style.Background(color)
+	}
+
+	var attrs tcell.AttrMask
+
+	for _, name := range spec.Attrs {
+		attr, ok := themeAttrs[name]
+		if !ok {
+			return style, fmt.Errorf("unknown attribute '%s'", name)
+		}
+
+		attrs |= attr
+	}
+
+	return style.Attributes(attrs), nil
+}
+
+func themeColor(name string) (tcell.Color, error) {
+	if name == "default" {
+		return tcell.ColorDefault, nil
+	}
+
+	if n, err := strconv.Atoi(name); err == nil && n >= 0 && n <= 255 {
+		return tcell.PaletteColor(n), nil
+	}
+
+	if color := tcell.GetColor(name); color != tcell.ColorDefault {
+		return color, nil
+	}
+
+	return tcell.ColorDefault, fmt.Errorf("unknown color '%s'", name)
+}
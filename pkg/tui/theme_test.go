@@ -0,0 +1,185 @@
+package tui
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/tobiassjosten/nogfx/pkg/mock"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuiltinThemes(t *testing.T) {
+	for _, name := range ThemeNames {
+		theme, ok := BuiltinTheme(name)
+		require.True(t, ok, name)
+		assert.Equal(t, name, theme.Name)
+
+		for style := range DefaultTheme().Styles {
+			assert.Contains(t, theme.Styles, style, name)
+		}
+	}
+
+	_, ok := BuiltinTheme("neon")
+	assert.False(t, ok)
+}
+
+func TestThemeStyle(t *testing.T) {
+	theme := &Theme{Styles: map[string]tcell.Style{
+		"input": style(tcell.ColorRed, tcell.ColorDefault),
+	}}
+
+	assert.Equal(t, style(tcell.ColorRed, tcell.ColorDefault), theme.Style("input"))
+	assert.Equal(t, darkTheme.Styles["vitals"], theme.Style("vitals"))
+	assert.Equal(t, tcell.Style{}, theme.Style("nonexistent"))
+
+	_, ok := theme.Lookup("nonexistent")
+	assert.False(t, ok)
+
+	assert.Equal(t, []tcell.Style{
+		darkTheme.Styles["vitals.health"],
+		darkTheme.Styles["vitals.health.empty"],
+	}, theme.vitalStyles("health"))
+
+	assert.Equal(t, []tcell.Style{
+		darkTheme.Styles["vitals"],
+		darkTheme.Styles["vitals.empty"],
+	}, theme.vitalStyles("spirit"))
+}
+
+func TestParseTheme(t *testing.T) {
+	tcs := map[string]struct {
+		data   string
+		styles map[string]tcell.Style
+		err    string
+	}{
+		"named colors": {
+			data: `{"styles":{"input":{"fg":"black","bg":"white"}}}`,
+			styles: map[string]tcell.Style{
+				"input": style(tcell.ColorBlack, tcell.ColorWhite),
+			},
+		},
+
+		"hex and palette colors": {
+			data: `{"styles":{"input":{"fg":"#ff8000","bg":"236"}}}`,
+			styles: map[string]tcell.Style{
+				"input": style(
+					tcell.NewHexColor(0xff8000), tcell.Color236,
+				),
+			},
+		},
+
+		"attributes": {
+			data: `{"styles":{"input":{"fg":"default","attrs":["bold","underline"]}}}`,
+			styles: map[string]tcell.Style{
+				"input": tcell.StyleDefault.Bold(true).Underline(true),
+			},
+		},
+
+		"base theme": {
+			data: `{"base":"light"}`,
+			styles: map[string]tcell.Style{
+				"input": lightTheme.Styles["input"],
+			},
+		},
+
+		"default base theme": {
+			data: `{}`,
+			styles: map[string]tcell.Style{
+				"input": darkTheme.Styles["input"],
+			},
+		},
+
+		"invalid json": {
+			data: `{`,
+			err:  "failed parsing theme: unexpected end of JSON input",
+		},
+
+		"unknown base theme": {
+			data: `{"base":"neon"}`,
+			err:  "unknown base theme 'neon'",
+		},
+
+		"unknown color": {
+			data: `{"styles":{"input":{"fg":"glitter"}}}`,
+			err:  "invalid style 'input': unknown color 'glitter'",
+		},
+
+		"out of range color": {
+			data: `{"styles":{"input":{"bg":"256"}}}`,
+			err:  "invalid style 'input': unknown color '256'",
+		},
+
+		"unknown attribute": {
+			data: `{"styles":{"input":{"attrs":["sparkly"]}}}`,
+			err:  "invalid style 'input': unknown attribute 'sparkly'",
+		},
+	}
+
+	for name, tc := range tcs {
+		t.Run(name, func(t *testing.T) {
+			theme, err := ParseTheme([]byte(tc.data))
+
+			if tc.err != "" {
+				require.NotNil(t, err)
+				assert.Equal(t, tc.err, err.Error())
+
+				return
+			}
+
+			require.Nil(t, err)
+
+			for name, style := range tc.styles {
+				assert.Equal(t, style, theme.Styles[name], name)
+			}
+		})
+	}
+}
+
+func TestLoadTheme(t *testing.T) {
+	theme, err := LoadTheme("")
+	require.Nil(t, err)
+	assert.Equal(t, darkTheme, theme)
+
+	theme, err = LoadTheme(ThemeHighContrast)
+	require.Nil(t, err)
+	assert.Equal(t, highContrastTheme, theme)
+
+	_, err = LoadTheme(filepath.Join(t.TempDir(), "nonexistent.json"))
+	require.NotNil(t, err)
+	assert.Contains(t, err.Error(), "unknown theme")
+
+	path := filepath.Join(t.TempDir(), "theme.json")
+	err = os.WriteFile(path, []byte(`{"styles":{"input":{"fg":"red"}}}`), 0600)
+	require.Nil(t, err)
+
+	theme, err = LoadTheme(path)
+	require.Nil(t, err)
+	assert.Equal(t, path, theme.Name)
+	assert.Equal(t, style(tcell.ColorRed, tcell.ColorDefault), theme.Styles["input"])
+}
+
+func TestSetTheme(t *testing.T) {
+	ui := NewTUI(&mock.ScreenMock{
+		SetCursorStyleFunc: func(_ tcell.CursorStyle) {},
+		SetStyleFunc:       func(_ tcell.Style) {},
+	})
+
+	ui.setCache(paneInput, Rows{})
+
+	ui.SetTheme(lightTheme)
+	assert.Equal(t, lightTheme, ui.theme)
+
+	_, ok := ui.getCache(paneInput)
+	assert.False(t, ok)
+
+	ui.input.buffer = []rune("x")
+	rows, _, _ := ui.RenderInput(3, 1)
+	assert.Equal(t, lightTheme.Styles["input"], rows[0][0].Style)
+
+	ui.SetTheme(nil)
+	assert.Equal(t, darkTheme, ui.theme)
+}
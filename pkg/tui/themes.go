@@ -0,0 +1,247 @@
+package tui
+
+import (
+	"github.com/gdamore/tcell/v2"
+)
+
+// style is a shorthand for creating styles with foreground and background
+// colors.
+func style(fg, bg tcell.Color) tcell.Style {
+	return tcell.StyleDefault.Foreground(fg).Background(bg)
+}
+
+var darkTheme = &Theme{
+	Name: ThemeDark,
+	Styles: map[string]tcell.Style{
+		"input": style(tcell.ColorWhite, tcell.Color235),
+
+		"vitals.gap":             style(tcell.ColorDefault, tcell.Color235),
+		"vitals":                 style(tcell.ColorBlack, tcell.Color250),
+		"vitals.empty":           style(tcell.ColorBlack, tcell.Color240),
+		"vitals.health":          style(tcell.ColorBlack, tcell.ColorGreen),
+		"vitals.health.empty":    style(tcell.ColorBlack, tcell.ColorDarkGreen),
+		"vitals.mana":            style(tcell.ColorBlack, tcell.ColorBlue),
+		"vitals.mana.empty":      style(tcell.ColorBlack, tcell.ColorDarkBlue),
+		"vitals.endurance":       style(tcell.ColorBlack, tcell.ColorTeal),
+		"vitals.endurance.empty": style(tcell.ColorBlack, tcell.ColorDarkCyan),
+		"vitals.willpower":       style(tcell.ColorBlack, tcell.ColorFuchsia),
+		"vitals.willpower.empty": style(tcell.ColorBlack, tcell.ColorRebeccaPurple),
+		"vitals.energy":          style(tcell.ColorBlack, tcell.ColorYellow),
+		"vitals.energy.empty":    style(tcell.ColorBlack, tcell.Color100),
+		"vitals.target":          style(tcell.ColorBlack, tcell.ColorRed),
+		"vitals.target.empty":    style(tcell.ColorBlack, tcell.ColorDarkRed),
+		"vitals.delta.gain":      style(tcell.ColorLime, tcell.ColorBlack),
+		"vitals.delta.loss":      style(tcell.ColorRed, tcell.ColorBlack),
+		"vitals.balance":         style(tcell.ColorBlack, tcell.Color250),
+		"vitals.balance.lost":    style(tcell.ColorWhite, tcell.ColorDarkRed),
+		"afflictions":            style(tcell.ColorSilver, tcell.ColorDefault),
+		"afflictions.eat":        style(tcell.ColorGreen, tcell.ColorDefault),
+		"afflictions.apply":      style(tcell.ColorYellow, tcell.ColorDefault),
+		"afflictions.smoke":      style(tcell.ColorFuchsia, tcell.ColorDefault),
+		"afflictions.sip":        style(tcell.ColorBlue, tcell.ColorDefault),
+		"afflictions.drink":      style(tcell.ColorBlue, tcell.ColorDefault),
+		"afflictions.focus":      style(tcell.ColorTeal, tcell.ColorDefault),
+		"afflictions.tree":       style(tcell.ColorOlive, tcell.ColorDefault),
+		"afflictions.missing":    style(tcell.ColorWhite, tcell.ColorDarkRed),
+		"minimap.unknown":        style(tcell.Color237, tcell.ColorDefault),
+		"minimap.vertical":       style(tcell.Color245, tcell.ColorDefault),
+		"minimap.ghost":          style(tcell.Color239, tcell.ColorDefault),
+		"minimap.tagged":         style(tcell.ColorFuchsia, tcell.ColorDefault),
+		"minimap.bank":           style(tcell.ColorYellow, tcell.ColorDefault),
+		"minimap.shop":           style(tcell.ColorLime, tcell.ColorDefault),
+		"minimap.sewer":          style(tcell.ColorOlive, tcell.ColorDefault),
+		"minimap.subdivision":    style(tcell.ColorSilver, tcell.ColorDefault),
+		"occupants":              style(tcell.ColorSilver, tcell.ColorDefault),
+		"occupants.header":       style(tcell.ColorWhite, tcell.ColorDefault).Bold(true),
+		"occupants.marked":       style(tcell.ColorYellow, tcell.ColorDefault),
+		"occupants.details":      style(tcell.Color242, tcell.ColorDefault),
+		"latency":                style(tcell.ColorSilver, tcell.ColorDefault),
+		"latency.sparkline":      style(tcell.ColorTeal, tcell.ColorDefault),
+		"latency.warning":        style(tcell.ColorRed, tcell.ColorDefault).Bold(true),
+		"rift":                   style(tcell.ColorSilver, tcell.ColorDefault),
+		"status":                 style(tcell.Color245, tcell.Color235),
+		"status.warning":         style(tcell.ColorRed, tcell.Color235).Bold(true),
+		"rift.low":               style(tcell.ColorRed, tcell.ColorDefault).Bold(true),
+		"sheet":                  style(tcell.ColorSilver, tcell.ColorDefault),
+		"sheet.name":             style(tcell.Color245, tcell.ColorDefault),
+		"skills":                 style(tcell.ColorSilver, tcell.ColorDefault),
+		"skills.group":           style(tcell.ColorWhite, tcell.ColorDefault).Bold(true),
+		"skills.description":     style(tcell.Color242, tcell.ColorDefault),
+		"browser.header":         style(tcell.ColorWhite, tcell.Color235),
+		"browser.footer":         style(tcell.Color245, tcell.Color235),
+		"browser.selected":       tcell.StyleDefault.Reverse(true),
+		"inspector.in":           style(tcell.ColorTeal, tcell.ColorDefault),
+		"inspector.out":          style(tcell.ColorOlive, tcell.ColorDefault),
+		"inspector.data":         style(tcell.ColorSilver, tcell.ColorDefault),
+		"comm.0":                 style(tcell.ColorTeal, tcell.ColorDefault),
+		"comm.1":                 style(tcell.ColorGreen, tcell.ColorDefault),
+		"comm.2":                 style(tcell.ColorYellow, tcell.ColorDefault),
+		"comm.3":                 style(tcell.ColorFuchsia, tcell.ColorDefault),
+		"comm.4":                 style(tcell.ColorBlue, tcell.ColorDefault),
+		"comm.5":                 style(tcell.ColorRed, tcell.ColorDefault),
+		"comm.6":                 style(tcell.ColorPurple, tcell.ColorDefault),
+		"comm.7":                 style(tcell.ColorOlive, tcell.ColorDefault),
+
+		// Environments worth telling apart when moving about, like
+		// water we have to swim through.
+		"minimap.environment.river":       style(tcell.ColorBlue, tcell.ColorDefault),
+		"minimap.environment.freshwater":  style(tcell.ColorBlue, tcell.ColorDefault),
+		"minimap.environment.ocean":       style(tcell.ColorBlue, tcell.ColorDefault),
+		"minimap.environment.deep ocean":  style(tcell.ColorNavy, tcell.ColorDefault),
+		"minimap.environment.reef":        style(tcell.ColorTeal, tcell.ColorDefault),
+		"minimap.environment.swamp":       style(tcell.ColorOlive, tcell.ColorDefault),
+		"minimap.environment.forest":      style(tcell.ColorGreen, tcell.ColorDefault),
+		"minimap.environment.jungle":      style(tcell.ColorGreen, tcell.ColorDefault),
+		"minimap.environment.desert":      style(tcell.ColorYellow, tcell.ColorDefault),
+		"minimap.environment.mountains":   style(tcell.ColorMaroon, tcell.ColorDefault),
+		"minimap.environment.polar":       style(tcell.ColorAqua, tcell.ColorDefault),
+		"minimap.environment.magma caves": style(tcell.ColorRed, tcell.ColorDefault),
+		"minimap.environment.sky":         style(tcell.ColorWhite, tcell.ColorDefault),
+	},
+}
+
+// extend creates a new theme from a base, with some styles overridden.
+func extend(base *Theme, name string, styles map[string]tcell.Style) *Theme {
+	theme := &Theme{Name: name, Styles: map[string]tcell.Style{}}
+
+	for name, style := range base.Styles {
+		theme.Styles[name] = style
+	}
+
+	for name, style := range styles {
+		theme.Styles[name] = style
+	}
+
+	return theme
+}
+
+var lightTheme = extend(darkTheme, ThemeLight, map[string]tcell.Style{
+	"input": style(tcell.ColorBlack, tcell.Color254),
+
+	"vitals.gap":          style(tcell.ColorDefault, tcell.Color252),
+	"vitals":              style(tcell.ColorBlack, tcell.Color245),
+	"vitals.empty":        style(tcell.ColorBlack, tcell.Color252),
+	"vitals.health.empty": style(tcell.ColorBlack, tcell.Color151),
+	"vitals.mana.empty":   style(tcell.ColorBlack, tcell.Color153),
+	"vitals.endurance":    style(tcell.ColorBlack, tcell.Color37),
+	"vitals.endurance.empty": style(
+		tcell.ColorBlack, tcell.Color159,
+	),
+	"vitals.willpower.empty": style(
+		tcell.ColorBlack, tcell.Color225,
+	),
+	"vitals.energy":       style(tcell.ColorBlack, tcell.Color178),
+	"vitals.energy.empty": style(tcell.ColorBlack, tcell.Color229),
+	"vitals.target.empty": style(tcell.ColorBlack, tcell.Color224),
+	"vitals.delta.gain":   style(tcell.ColorGreen, tcell.ColorWhite),
+	"vitals.delta.loss":   style(tcell.ColorMaroon, tcell.ColorWhite),
+	"vitals.balance":      style(tcell.ColorBlack, tcell.Color245),
+	"vitals.balance.lost": style(tcell.ColorWhite, tcell.ColorMaroon),
+	"afflictions":         style(tcell.ColorGray, tcell.ColorDefault),
+	"afflictions.apply":   style(tcell.ColorOlive, tcell.ColorDefault),
+	"afflictions.smoke":   style(tcell.ColorPurple, tcell.ColorDefault),
+	"afflictions.sip":     style(tcell.ColorNavy, tcell.ColorDefault),
+	"afflictions.drink":   style(tcell.ColorNavy, tcell.ColorDefault),
+	"afflictions.missing": style(tcell.ColorWhite, tcell.ColorMaroon),
+	"minimap.unknown":     style(tcell.Color250, tcell.ColorDefault),
+	"minimap.vertical":    style(tcell.Color242, tcell.ColorDefault),
+	"minimap.ghost":       style(tcell.Color252, tcell.ColorDefault),
+	"minimap.tagged":      style(tcell.ColorPurple, tcell.ColorDefault),
+	"minimap.bank":        style(tcell.ColorOlive, tcell.ColorDefault),
+	"minimap.shop":        style(tcell.ColorGreen, tcell.ColorDefault),
+	"minimap.subdivision": style(tcell.ColorGray, tcell.ColorDefault),
+	"occupants":           style(tcell.ColorGray, tcell.ColorDefault),
+	"occupants.header":    style(tcell.ColorBlack, tcell.ColorDefault).Bold(true),
+	"occupants.marked":    style(tcell.ColorOlive, tcell.ColorDefault),
+	"occupants.details":   style(tcell.Color245, tcell.ColorDefault),
+	"latency":             style(tcell.ColorGray, tcell.ColorDefault),
+	"latency.sparkline":   style(tcell.ColorNavy, tcell.ColorDefault),
+	"latency.warning":     style(tcell.ColorMaroon, tcell.ColorDefault).Bold(true),
+	"rift":                style(tcell.ColorGray, tcell.ColorDefault),
+	"status":              style(tcell.Color242, tcell.Color254),
+	"status.warning":      style(tcell.ColorMaroon, tcell.Color254).Bold(true),
+	"rift.low":            style(tcell.ColorMaroon, tcell.ColorDefault).Bold(true),
+	"sheet":               style(tcell.ColorBlack, tcell.ColorDefault),
+	"sheet.name":          style(tcell.ColorGray, tcell.ColorDefault),
+	"skills":              style(tcell.ColorGray, tcell.ColorDefault),
+	"skills.group":        style(tcell.ColorBlack, tcell.ColorDefault).Bold(true),
+	"skills.description":  style(tcell.Color245, tcell.ColorDefault),
+	"browser.header":      style(tcell.ColorBlack, tcell.Color254),
+	"browser.footer":      style(tcell.Color242, tcell.Color254),
+	"inspector.in":        style(tcell.ColorNavy, tcell.ColorDefault),
+	"inspector.out":       style(tcell.ColorOlive, tcell.ColorDefault),
+	"inspector.data":      style(tcell.ColorGray, tcell.ColorDefault),
+	"comm.2":              style(tcell.ColorOlive, tcell.ColorDefault),
+	"comm.3":              style(tcell.ColorPurple, tcell.ColorDefault),
+	"comm.4":              style(tcell.ColorNavy, tcell.ColorDefault),
+	"comm.5":              style(tcell.ColorMaroon, tcell.ColorDefault),
+})
+
+var highContrastTheme = extend(darkTheme, ThemeHighContrast, map[string]tcell.Style{
+	"input": style(tcell.ColorWhite, tcell.ColorBlack).Bold(true),
+
+	"vitals.gap":             style(tcell.ColorDefault, tcell.ColorBlack),
+	"vitals":                 style(tcell.ColorBlack, tcell.ColorWhite),
+	"vitals.empty":           style(tcell.ColorWhite, tcell.ColorBlack),
+	"vitals.health":          style(tcell.ColorBlack, tcell.ColorLime),
+	"vitals.health.empty":    style(tcell.ColorWhite, tcell.ColorBlack),
+	"vitals.mana":            style(tcell.ColorBlack, tcell.ColorAqua),
+	"vitals.mana.empty":      style(tcell.ColorWhite, tcell.ColorBlack),
+	"vitals.endurance":       style(tcell.ColorBlack, tcell.ColorWhite),
+	"vitals.endurance.empty": style(tcell.ColorWhite, tcell.ColorBlack),
+	"vitals.willpower":       style(tcell.ColorBlack, tcell.ColorFuchsia),
+	"vitals.willpower.empty": style(tcell.ColorWhite, tcell.ColorBlack),
+	"vitals.energy":          style(tcell.ColorBlack, tcell.ColorYellow),
+	"vitals.energy.empty":    style(tcell.ColorWhite, tcell.ColorBlack),
+	"vitals.target":          style(tcell.ColorBlack, tcell.ColorRed),
+	"vitals.target.empty":    style(tcell.ColorWhite, tcell.ColorBlack),
+	"vitals.delta.gain":      style(tcell.ColorLime, tcell.ColorBlack).Bold(true),
+	"vitals.delta.loss":      style(tcell.ColorRed, tcell.ColorBlack).Bold(true),
+	"vitals.balance":         style(tcell.ColorBlack, tcell.ColorWhite),
+	"vitals.balance.lost":    style(tcell.ColorWhite, tcell.ColorRed).Bold(true),
+	"afflictions":            style(tcell.ColorWhite, tcell.ColorDefault),
+	"afflictions.eat":        style(tcell.ColorLime, tcell.ColorDefault),
+	"afflictions.sip":        style(tcell.ColorAqua, tcell.ColorDefault),
+	"afflictions.drink":      style(tcell.ColorAqua, tcell.ColorDefault),
+	"afflictions.focus":      style(tcell.ColorAqua, tcell.ColorDefault),
+	"afflictions.tree":       style(tcell.ColorYellow, tcell.ColorDefault),
+	"afflictions.missing":    style(tcell.ColorWhite, tcell.ColorRed).Bold(true),
+	"minimap.unknown":        style(tcell.Color244, tcell.ColorDefault),
+	"minimap.vertical":       style(tcell.ColorWhite, tcell.ColorDefault),
+	"minimap.ghost":          style(tcell.ColorGray, tcell.ColorDefault),
+	"browser.header":         style(tcell.ColorBlack, tcell.ColorWhite),
+	"browser.footer":         style(tcell.ColorWhite, tcell.ColorBlack),
+	"comm.0":                 style(tcell.ColorAqua, tcell.ColorDefault),
+	"comm.1":                 style(tcell.ColorLime, tcell.ColorDefault),
+	"comm.4":                 style(tcell.ColorWhite, tcell.ColorDefault),
+	"comm.6":                 style(tcell.ColorFuchsia, tcell.ColorDefault),
+	"comm.7":                 style(tcell.ColorYellow, tcell.ColorDefault),
+})
+
+// The colorblind theme uses the Okabe-Ito palette for its bars, which stays
+// distinguishable with the common forms of color vision deficiency.
+var colorblindTheme = extend(darkTheme, ThemeColorblind, map[string]tcell.Style{
+	"vitals.health":          style(tcell.ColorBlack, tcell.NewHexColor(0x009e73)),
+	"vitals.health.empty":    style(tcell.ColorBlack, tcell.NewHexColor(0x004f39)),
+	"vitals.mana":            style(tcell.ColorBlack, tcell.NewHexColor(0x0072b2)),
+	"vitals.mana.empty":      style(tcell.ColorBlack, tcell.NewHexColor(0x003959)),
+	"vitals.endurance":       style(tcell.ColorBlack, tcell.NewHexColor(0x56b4e9)),
+	"vitals.endurance.empty": style(tcell.ColorBlack, tcell.NewHexColor(0x2b5a74)),
+	"vitals.willpower":       style(tcell.ColorBlack, tcell.NewHexColor(0xcc79a7)),
+	"vitals.willpower.empty": style(tcell.ColorBlack, tcell.NewHexColor(0x663c53)),
+	"vitals.energy":          style(tcell.ColorBlack, tcell.NewHexColor(0xf0e442)),
+	"vitals.energy.empty":    style(tcell.ColorBlack, tcell.NewHexColor(0x787221)),
+	"vitals.target":          style(tcell.ColorBlack, tcell.NewHexColor(0xd55e00)),
+	"vitals.target.empty":    style(tcell.ColorBlack, tcell.NewHexColor(0x6b2f00)),
+	"vitals.delta.gain":      style(tcell.NewHexColor(0x56b4e9), tcell.ColorBlack),
+	"vitals.delta.loss":      style(tcell.NewHexColor(0xe69f00), tcell.ColorBlack),
+	"vitals.balance.lost":    style(tcell.ColorBlack, tcell.NewHexColor(0xe69f00)),
+	"afflictions.eat":        style(tcell.NewHexColor(0x009e73), tcell.ColorDefault),
+	"afflictions.apply":      style(tcell.NewHexColor(0xf0e442), tcell.ColorDefault),
+	"afflictions.smoke":      style(tcell.NewHexColor(0xcc79a7), tcell.ColorDefault),
+	"afflictions.sip":        style(tcell.NewHexColor(0x56b4e9), tcell.ColorDefault),
+	"afflictions.drink":      style(tcell.NewHexColor(0x56b4e9), tcell.ColorDefault),
+	"afflictions.focus":      style(tcell.NewHexColor(0x0072b2), tcell.ColorDefault),
+	"afflictions.tree":       style(tcell.NewHexColor(0xe69f00), tcell.ColorDefault),
+	"afflictions.missing":    style(tcell.ColorBlack, tcell.NewHexColor(0xe69f00)),
+})
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"sync"
+	"time"
 
 	"github.com/tobiassjosten/nogfx/pkg"
 	"github.com/tobiassjosten/nogfx/pkg/navigation"
@@ -16,6 +17,11 @@ type TUI struct {
 	screen tcell.Screen
 
 	layout *Layout
+	theme  *Theme
+
+	// A theme not among the built-in ones, like one loaded from a file,
+	// kept to cycle back to.
+	customTheme *Theme
 
 	cacheMutex sync.Mutex
 	panesCache map[string]Rows
@@ -27,10 +33,51 @@ type TUI struct {
 	outputs chan []byte
 	output  *Output
 
+	// Communications, added from the engine's goroutine.
+	commMutex sync.Mutex
+	comm      *Output
+
+	// GMCP messages sent and received, most recent last, formatted for
+	// the inspector. They're added from the engine's goroutine.
+	gmcpMutex sync.Mutex
+	gmcp      []inspectorEntry
+
 	character pkg.Character
+	latency   pkg.Latency
+	occupants pkg.Occupants
+	rift      []pkg.RiftItem
 	room      *navigation.Room
+	skills    []pkg.SkillGroup
+	status    pkg.Status
 	target    *pkg.Target
 
+	// Recent changes of vitals, shown next to their bars for a while, and
+	// expired by a timer of its own.
+	deltasMutex sync.Mutex
+	deltas      map[string]int
+	deltasTimer *time.Timer
+
+	// All rooms of the map, copied for browsing since the map itself is
+	// updated from the engine's goroutine.
+	roomsMutex sync.Mutex
+	rooms      []*navigation.Room
+
+	// Whether the character sheet is shown.
+	sheet bool
+
+	// The full-screen map browser, replacing all panes while it's shown.
+	browser *Browser
+
+	// The full-screen skills browser, likewise replacing all panes.
+	skillBrowser *SkillBrowser
+
+	// The full-screen GMCP inspector, likewise replacing all panes.
+	inspector *Inspector
+
+	// Whether to clear the screen on the next draw, when switching from
+	// or to the full-screen browsers.
+	clear bool
+
 	running bool
 }
 
@@ -42,6 +89,7 @@ func NewTUI(screen tcell.Screen) *TUI {
 
 	tui := &TUI{
 		screen: screen,
+		theme:  DefaultTheme(),
 
 		panesCache: map[string]Rows{},
 
@@ -50,8 +98,10 @@ func NewTUI(screen tcell.Screen) *TUI {
 
 		outputs: make(chan []byte),
 		output:  &Output{},
+
+		comm: &Output{},
 	}
-	tui.layout = &Layout{tui}
+	tui.layout = newLayout(tui, DefaultLayout())
 
 	screen.SetStyle(outputStyle)
 	screen.SetCursorStyle(tcell.CursorStyleBlinkingBlock)
@@ -99,15 +149,32 @@ func (tui *TUI) getCache(name string) (Rows, bool) {
 
 // SetCharacter updates the current character and causes a repaint.
 func (tui *TUI) SetCharacter(character pkg.Character) {
+	tui.setDeltas(tui.character.Vitals, character.Vitals)
+
 	tui.character = character
+	tui.setCache(paneAfflictions, nil)
+	tui.setCache(paneSheet, nil)
 	tui.setCache(paneVitals, nil)
 	tui.Draw()
 }
 
+// SetMap updates the rooms available for browsing.
+func (tui *TUI) SetMap(nmap *navigation.Map) {
+	rooms := make([]*navigation.Room, 0, len(nmap.Rooms))
+	for _, room := range nmap.Rooms {
+		rooms = append(rooms, room)
+	}
+
+	tui.roomsMutex.Lock()
+	tui.rooms = rooms
+	tui.roomsMutex.Unlock()
+}
+
 // SetRoom updates the current room and causes a repaint.
 func (tui *TUI) SetRoom(room *navigation.Room) {
 	tui.room = room
 	tui.setCache(paneMap, nil)
+	tui.setCache(paneStatus, nil)
 	tui.Draw()
 }
 
@@ -192,8 +259,27 @@ func (tui *TUI) Draw() {
 		return
 	}
 
-	for _, p := range tui.layout.panes() {
-		tui.paint(p.x, p.y, p.rows)
+	if tui.clear {
+		tui.clear = false
+		tui.screen.Clear()
+	}
+
+	if tui.browser != nil {
+		width, height := tui.screen.Size()
+		tui.cursorpos = nil
+		tui.paint(0, 0, tui.RenderBrowser(width, height))
+	} else if tui.skillBrowser != nil {
+		width, height := tui.screen.Size()
+		tui.cursorpos = nil
+		tui.paint(0, 0, tui.RenderSkillBrowser(width, height))
+	} else if tui.inspector != nil {
+		width, height := tui.screen.Size()
+		tui.cursorpos = nil
+		tui.paint(0, 0, tui.RenderInspector(width, height))
+	} else {
+		for _, p := range tui.layout.panes() {
+			tui.paint(p.x, p.y, p.rows)
+		}
 	}
 
 	if pos := tui.cursorpos; pos != nil {
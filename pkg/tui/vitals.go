@@ -1,76 +1,19 @@
 package tui
 
 import (
+	"fmt"
 	"math"
+	"sort"
 	"strconv"
+	"time"
 
 	"github.com/tobiassjosten/nogfx/pkg"
 
 	"github.com/gdamore/tcell/v2"
 )
 
-var (
-	// Predefined styles of some common vitals. i=0 is full, i=1 is empty.
-	vitalStyles = map[string][]tcell.Style{
-		// Fallback style, for when none other matches.
-		"": {
-			tcell.StyleDefault.
-				Background(tcell.Color250).
-				Foreground(tcell.ColorBlack),
-			tcell.StyleDefault.
-				Background(tcell.Color240).
-				Foreground(tcell.ColorBlack),
-		},
-		"health": {
-			tcell.StyleDefault.
-				Background(tcell.ColorGreen).
-				Foreground(tcell.ColorBlack),
-			tcell.StyleDefault.
-				Background(tcell.ColorDarkGreen).
-				Foreground(tcell.ColorBlack),
-		},
-		"mana": {
-			tcell.StyleDefault.
-				Background(tcell.ColorBlue).
-				Foreground(tcell.ColorBlack),
-			tcell.StyleDefault.
-				Background(tcell.ColorDarkBlue).
-				Foreground(tcell.ColorBlack),
-		},
-		"endurance": {
-			tcell.StyleDefault.
-				Background(tcell.ColorTeal).
-				Foreground(tcell.ColorBlack),
-			tcell.StyleDefault.
-				Background(tcell.ColorDarkCyan).
-				Foreground(tcell.ColorBlack),
-		},
-		"willpower": {
-			tcell.StyleDefault.
-				Background(tcell.ColorFuchsia).
-				Foreground(tcell.ColorBlack),
-			tcell.StyleDefault.
-				Background(tcell.ColorRebeccaPurple).
-				Foreground(tcell.ColorBlack),
-		},
-		"energy": {
-			tcell.StyleDefault.
-				Background(tcell.ColorYellow).
-				Foreground(tcell.ColorBlack),
-			tcell.StyleDefault.
-				Background(tcell.Color100).
-				Foreground(tcell.ColorBlack),
-		},
-		"target": {
-			tcell.StyleDefault.
-				Background(tcell.ColorRed).
-				Foreground(tcell.ColorBlack),
-			tcell.StyleDefault.
-				Background(tcell.ColorDarkRed).
-				Foreground(tcell.ColorBlack),
-		},
-	}
-)
+// How long vital deltas are shown.
+var deltaDuration = 3 * time.Second
 
 // RenderVitals renders the current Vitals.
 func (tui *TUI) RenderVitals(width int) Rows {
@@ -109,25 +52,51 @@ func (tui *TUI) RenderVitals(width int) Rows {
 		}
 	}
 
-	gapStyle := (tcell.Style{}).Background(tcell.Color235)
+	gapStyle := tui.theme.Style("vitals.gap")
+
+	balances := Row{}
+
+	for _, name := range balanceOrder(tui.character.Balances) {
+		balances = balances.append(NewCell(' ', gapStyle))
+		balances = balances.append(RenderBalance(
+			name, tui.character.Balances[name], []tcell.Style{
+				tui.theme.Style("vitals.balance"),
+				tui.theme.Style("vitals.balance.lost"),
+			},
+		)...)
+	}
+
+	// Leave the bulk of the row to the vitals.
+	if len(balances) > width/3 {
+		balances = Row{}
+	}
+
+	width -= len(balances)
 
 	row := Row{}
 
 	for i, name := range vorder {
-		styles, ok := vitalStyles[name]
-		if !ok {
-			styles = vitalStyles[""]
-		}
-
 		row = row.append(NewRow(min(1, i), NewCell(' ', gapStyle))...)
-		row = row.append(RenderVital(
+		vrow := RenderVital(
 			tui.character.Vitals[name],
 			(width-len(row))/(len(vorder)-i),
-			styles,
-		)...)
+			tui.theme.vitalStyles(name),
+		)
+
+		if delta := tui.delta(name); delta != 0 {
+			vrow = RenderDelta(
+				vrow, tui.character.Vitals[name], delta,
+				[]tcell.Style{
+					tui.theme.Style("vitals.delta.gain"),
+					tui.theme.Style("vitals.delta.loss"),
+				},
+			)
+		}
+
+		row = row.append(vrow...)
 	}
 
-	rows := Rows{row}
+	rows := Rows{row.append(balances...)}
 
 	tui.setCache(paneVitals, rows)
 
@@ -153,3 +122,118 @@ func RenderVital(vital pkg.CharacterVital, width int, styles []tcell.Style) Row
 
 	return row
 }
+
+// setDeltas registers changes between the previous and current vitals, to be
+// shown for a while.
+func (tui *TUI) setDeltas(previous, current map[string]pkg.CharacterVital) {
+	deltas := map[string]int{}
+
+	for name, vital := range current {
+		if prev, ok := previous[name]; ok && prev.Value != vital.Value {
+			deltas[name] = vital.Value - prev.Value
+		}
+	}
+
+	if len(deltas) == 0 {
+		return
+	}
+
+	tui.deltasMutex.Lock()
+	defer tui.deltasMutex.Unlock()
+
+	tui.deltas = deltas
+
+	if tui.deltasTimer != nil {
+		tui.deltasTimer.Stop()
+	}
+
+	tui.deltasTimer = time.AfterFunc(deltaDuration, func() {
+		tui.deltasMutex.Lock()
+		tui.deltas = nil
+		tui.deltasMutex.Unlock()
+
+		tui.setCache(paneVitals, nil)
+		tui.Draw()
+	})
+}
+
+// delta looks up the recent change of a vital, if any.
+func (tui *TUI) delta(name string) int {
+	tui.deltasMutex.Lock()
+	defer tui.deltasMutex.Unlock()
+
+	return tui.deltas[name]
+}
+
+// RenderDelta adds a signed delta to the right end of a rendered vital, if
+// there's room for it next to the vital's value. i=0 of styles is for gains
+// and i=1 for losses.
+func RenderDelta(row Row, vital pkg.CharacterVital, delta int, styles []tcell.Style) Row {
+	style := styles[0]
+	text := fmt.Sprintf("+%d", delta)
+
+	if delta < 0 {
+		style = styles[1]
+		text = strconv.Itoa(delta)
+	}
+
+	// The value is centered, so we need as much room to its right as the
+	// delta takes up, along with a space on either side.
+	value := len(strconv.Itoa(vital.Value))
+	if len(row)-(len(row)-value)/2-value < len(text)+2 {
+		return row
+	}
+
+	row = append(Row{}, row...)
+
+	for i, r := range text {
+		row[len(row)-1-len(text)+i] = NewCell(r, style)
+	}
+
+	return row
+}
+
+// balanceOrder sorts balances with balance and equilibrium first, followed by
+// any others alphabetically.
+func balanceOrder(balances map[string]pkg.CharacterBalance) []string {
+	order := []string{}
+
+	for _, name := range []string{"balance", "equilibrium"} {
+		if _, ok := balances[name]; ok {
+			order = append(order, name)
+		}
+	}
+
+	others := []string{}
+
+	for name := range balances {
+		if name != "balance" && name != "equilibrium" {
+			others = append(others, name)
+		}
+	}
+
+	sort.Strings(others)
+
+	return append(order, others...)
+}
+
+// RenderBalance renders an indicator of the given balance, with the duration
+// of its most recent loss. i=0 of styles is for when it's had and i=1 for
+// when it's lost.
+func RenderBalance(name string, balance pkg.CharacterBalance, styles []tcell.Style) Row {
+	style := styles[0]
+	if !balance.Has {
+		style = styles[1]
+	}
+
+	text := ""
+	if name != "" {
+		text = " " + string([]rune(name)[0])
+	}
+
+	if balance.Last > 0 {
+		text += fmt.Sprintf(" %.1fs", balance.Last.Seconds())
+	}
+
+	return NewRowFromRunes([]rune(text+" "), style)
+}
@@ -2,7 +2,9 @@ package tui_test
 
 import (
 	"context"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/tobiassjosten/nogfx/pkg"
 	"github.com/tobiassjosten/nogfx/pkg/mock"
@@ -62,6 +64,14 @@ var (
 	unknownEmptyStyle = tcell.StyleDefault.
 				Background(tcell.Color240).
 				Foreground(tcell.ColorBlack)
+
+	balanceStyle = tcell.StyleDefault.
+			Background(tcell.Color250).
+			Foreground(tcell.ColorBlack)
+
+	balanceLostStyle = tcell.StyleDefault.
+				Background(tcell.ColorDarkRed).
+				Foreground(tcell.ColorWhite)
 )
 
 func TestRenderVital(t *testing.T) {
@@ -146,13 +156,174 @@ func TestRenderVital(t *testing.T) {
 	}
 }
 
+func TestRenderBalance(t *testing.T) {
+	tcs := map[string]struct {
+		name    string
+		balance pkg.CharacterBalance
+		row     string
+		style   tcell.Style
+	}{
+		"had": {
+			name:    "balance",
+			balance: pkg.CharacterBalance{Has: true},
+			row:     " b ",
+			style:   balanceStyle,
+		},
+
+		"lost": {
+			name:    "equilibrium",
+			balance: pkg.CharacterBalance{},
+			row:     " e ",
+			style:   balanceLostStyle,
+		},
+
+		"recovered": {
+			name: "balance",
+			balance: pkg.CharacterBalance{
+				Has:  true,
+				Last: 2850 * time.Millisecond,
+			},
+			row:   " b 2.9s ",
+			style: balanceStyle,
+		},
+	}
+
+	for name, tc := range tcs {
+		t.Run(name, func(t *testing.T) {
+			row := tui.RenderBalance(tc.name, tc.balance, []tcell.Style{
+				balanceStyle, balanceLostStyle,
+			})
+
+			assert.Equal(t, tc.row, row.String())
+
+			for _, cell := range row {
+				assert.Equal(t, tc.style, cell.Style)
+			}
+		})
+	}
+}
+
+func TestRenderDelta(t *testing.T) {
+	gainStyle := tcell.StyleDefault.
+		Background(tcell.ColorBlack).
+		Foreground(tcell.ColorLime)
+
+	lossStyle := tcell.StyleDefault.
+		Background(tcell.ColorBlack).
+		Foreground(tcell.ColorRed)
+
+	tcs := map[string]struct {
+		vital pkg.CharacterVital
+		delta int
+		width int
+		row   string
+		style tcell.Style
+	}{
+		"loss": {
+			vital: pkg.CharacterVital{Value: 5, Max: 10},
+			delta: -3,
+			width: 9,
+			row:   "    5 -3 ",
+			style: lossStyle,
+		},
+
+		"gain": {
+			vital: pkg.CharacterVital{Value: 8, Max: 10},
+			delta: 3,
+			width: 9,
+			row:   "    8 +3 ",
+			style: gainStyle,
+		},
+
+		"cramped": {
+			vital: pkg.CharacterVital{Value: 5, Max: 10},
+			delta: -30,
+			width: 9,
+			row:   "    5    ",
+		},
+	}
+
+	for name, tc := range tcs {
+		t.Run(name, func(t *testing.T) {
+			row := tui.RenderVital(tc.vital, tc.width, []tcell.Style{
+				healthFullStyle, healthEmptyStyle,
+			})
+
+			row = tui.RenderDelta(row, tc.vital, tc.delta, []tcell.Style{
+				gainStyle, lossStyle,
+			})
+			assert.Equal(t, tc.row, row.String())
+
+			if tc.style != (tcell.Style{}) {
+				assert.Equal(t, tc.style, row[len(row)-2].Style)
+			}
+		})
+	}
+}
+
+func TestRenderVitalsDeltas(t *testing.T) {
+	ui := tui.NewTUI(&mock.ScreenMock{
+		HideCursorFunc:     func() {},
+		SetCursorStyleFunc: func(_ tcell.CursorStyle) {},
+		SetStyleFunc:       func(_ tcell.Style) {},
+	})
+
+	ui.SetCharacter(pkg.Character{Vitals: map[string]pkg.CharacterVital{
+		"health": {Value: 100, Max: 100},
+		"mana":   {Value: 50, Max: 100},
+	}})
+
+	assert.Equal(t,
+		[]string{"     100      " + " " + "      50      "},
+		ui.RenderVitals(29).Strings(),
+	)
+
+	ui.SetCharacter(pkg.Character{Vitals: map[string]pkg.CharacterVital{
+		"health": {Value: 90, Max: 100},
+		"mana":   {Value: 50, Max: 100},
+	}})
+
+	assert.Equal(t,
+		[]string{"      90  -10 " + " " + "      50      "},
+		ui.RenderVitals(29).Strings(),
+	)
+}
+
+func TestDeltasExpire(t *testing.T) {
+	defer tui.SetDeltaDuration(100 * time.Millisecond)()
+
+	ui := tui.NewTUI(&mock.ScreenMock{
+		SetCursorStyleFunc: func(_ tcell.CursorStyle) {},
+		SetStyleFunc:       func(_ tcell.Style) {},
+	})
+
+	render := func() string {
+		return strings.Join(ui.RenderVitalsAnew(30).Strings(), "\n")
+	}
+
+	ui.SetCharacter(pkg.Character{Vitals: map[string]pkg.CharacterVital{
+		"health": {Value: 100, Max: 100},
+	}})
+	ui.SetCharacter(pkg.Character{Vitals: map[string]pkg.CharacterVital{
+		"health": {Value: 90, Max: 100},
+	}})
+
+	assert.Contains(t, render(), "-10")
+
+	// Rendering while the timer expires the deltas mustn't race.
+	assert.Eventually(t, func() bool {
+		return !strings.Contains(render(), "-10")
+	}, time.Second, time.Millisecond)
+}
+
 func TestRenderVitals(t *testing.T) {
 	tcs := map[string]struct {
-		vitals map[string]pkg.CharacterVital
-		width  int
-		height int
-		row    tui.Row
-		err    string
+		vitals   map[string]pkg.CharacterVital
+		balances map[string]pkg.CharacterBalance
+		width    int
+		height   int
+		row      tui.Row
+		err      string
 	}{
 		"too short": {
 			width:  1,
@@ -275,6 +446,50 @@ func TestRenderVitals(t *testing.T) {
 				tui.NewCell(' ', willpowerEmptyStyle),
 			},
 		},
+
+		"width 12 health 1/1 balance": {
+			vitals: map[string]pkg.CharacterVital{
+				"health": {Value: 1, Max: 1},
+			},
+			balances: map[string]pkg.CharacterBalance{
+				"balance": {Has: true},
+			},
+			width:  12,
+			height: 5,
+			row: tui.Row{
+				tui.NewCell(' ', healthFullStyle),
+				tui.NewCell(' ', healthFullStyle),
+				tui.NewCell(' ', healthFullStyle),
+				tui.NewCell('1', healthFullStyle),
+				tui.NewCell(' ', healthFullStyle),
+				tui.NewCell(' ', healthFullStyle),
+				tui.NewCell(' ', healthFullStyle),
+				tui.NewCell(' ', healthFullStyle),
+				tui.NewCell(' ', gapStyle),
+				tui.NewCell(' ', balanceStyle),
+				tui.NewCell('b', balanceStyle),
+				tui.NewCell(' ', balanceStyle),
+			},
+		},
+
+		"width 6 health 1/1 cramped balance": {
+			vitals: map[string]pkg.CharacterVital{
+				"health": {Value: 1, Max: 1},
+			},
+			balances: map[string]pkg.CharacterBalance{
+				"balance": {Has: true},
+			},
+			width:  6,
+			height: 5,
+			row: tui.Row{
+				tui.NewCell(' ', healthFullStyle),
+				tui.NewCell(' ', healthFullStyle),
+				tui.NewCell('1', healthFullStyle),
+				tui.NewCell(' ', healthFullStyle),
+				tui.NewCell(' ', healthFullStyle),
+				tui.NewCell(' ', healthFullStyle),
+			},
+		},
 	}
 
 	for name, tc := range tcs {
@@ -308,7 +523,10 @@ func TestRenderVitals(t *testing.T) {
 
 			ui := tui.NewTUI(screen)
 
-			ui.SetCharacter(pkg.Character{Vitals: tc.vitals})
+			ui.SetCharacter(pkg.Character{
+				Vitals:   tc.vitals,
+				Balances: tc.balances,
+			})
 
 			_ = ui.Run(ctx)
 
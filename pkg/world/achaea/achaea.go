@@ -4,6 +4,8 @@ import (
 	"bytes"
 	"fmt"
 	"log"
+	"sort"
+	"time"
 
 	"github.com/tobiassjosten/nogfx/pkg"
 	"github.com/tobiassjosten/nogfx/pkg/gmcp"
@@ -13,48 +15,181 @@ import (
 	"github.com/tobiassjosten/nogfx/pkg/telnet"
 	amodule "github.com/tobiassjosten/nogfx/pkg/world/achaea/module"
 	gmodule "github.com/tobiassjosten/nogfx/pkg/world/module"
+
+	"golang.org/x/exp/maps"
+	"golang.org/x/exp/slices"
 )
 
 // World is an Achaea-specific implementation of the pkg.World interface.
 type World struct {
 	client pkg.Client
+	config *pkg.Config
 
 	ui       pkg.UI
 	uiVitals map[string]struct{}
 
 	triggers []pkg.Trigger
 
+	// Results of background work, to be applied from the engine's loop.
+	tasks chan func()
+
+	// Communications to remove from the main output, as they're only
+	// wanted in the communications pane.
+	comms []pendingComm
+
+	// Vital changes not yet summarized in the output.
+	deltas []vitalDelta
+
+	// Whether the character's full status has been received, after which
+	// changes to it are worth pointing out.
+	statusKnown bool
+
+	// Where the map is persisted, or empty to keep it in memory only.
+	mapPath string
+
+	// Whether saving the map is already scheduled.
+	mapSaving bool
+
+	// Where the official map can be downloaded, as announced by the game.
+	mapURL string
+
+	// Remaining steps of an ongoing walk to another room.
+	walk []navigation.Step
+
 	Character *Character
+	Inventory *Inventory
+	Map       *navigation.Map
+	Occupants *Occupants
+	Rift      *Rift
 	Room      *navigation.Room
+	Skills    *Skills
 	Target    *Target
+
+	// Unknown holds the latest GMCP messages we don't have types for, by
+	// their IDs.
+	Unknown map[string]*gmcp.RawMessage
 }
 
 // NewWorld creates a new Achaea-specific pkg.World.
-func NewWorld(client pkg.Client, ui pkg.UI) pkg.World {
+func NewWorld(client pkg.Client, ui pkg.UI, config *pkg.Config) pkg.World {
 	world := &World{
 		client: client,
+		config: config,
 
 		ui:       ui,
 		uiVitals: map[string]struct{}{},
 
-		Character: &Character{},
+		tasks: make(chan func()),
+
+		mapPath: config.Path("maps", "achaea.json"),
+
+		Character: &Character{
+			Keepup: config.Defences.Keepup,
+		},
+		Inventory: NewInventory(),
+		Map:       navigation.NewMap(),
+		Occupants: &Occupants{},
+		Rift:      &Rift{},
+		Skills:    &Skills{},
 		Target:    NewTarget(client),
+		Unknown:   map[string]*gmcp.RawMessage{},
+	}
+
+	if world.mapPath != "" {
+		nmap, err := navigation.LoadMap(world.mapPath)
+		if err != nil {
+			log.Printf("failed loading map: %s", err)
+		} else {
+			world.Map = nmap
+		}
 	}
 
 	// @todo Make sure these are ordered correctly. Potentially by adding a weight
 	// property for sorting?
 	var modules = []pkg.Module{
 		gmodule.NewRepeatInput(),
-		amodule.NewLearnMultipleLessons(),
+		amodule.NewLearnMultipleLessons(world.Skills.Rank),
 	}
 
 	for _, module := range modules {
 		world.triggers = append(world.triggers, module.Triggers()...)
 	}
 
+	world.triggers = append(world.triggers, pkg.Trigger{
+		Kind:     pkg.Input,
+		Pattern:  []byte("balances"),
+		Callback: world.onBalances,
+	}, pkg.Trigger{
+		Kind:     pkg.Input,
+		Pattern:  []byte("map import {*}"),
+		Callback: world.onMapImport,
+	}, pkg.Trigger{
+		Kind:     pkg.Input,
+		Pattern:  []byte("map download"),
+		Callback: world.onMapDownload,
+	}, pkg.Trigger{
+		Kind:     pkg.Input,
+		Pattern:  []byte("goto {*}"),
+		Callback: world.onGoto,
+	}, pkg.Trigger{
+		Kind:     pkg.Input,
+		Pattern:  []byte("room info"),
+		Callback: world.onRoomInfo,
+	}, pkg.Trigger{
+		Kind:     pkg.Input,
+		Pattern:  []byte("room note {*}"),
+		Callback: world.onRoomNote,
+	}, pkg.Trigger{
+		Kind:     pkg.Input,
+		Pattern:  []byte("room unnote"),
+		Callback: world.onRoomUnnote,
+	}, pkg.Trigger{
+		Kind:     pkg.Input,
+		Pattern:  []byte("room tag {*}"),
+		Callback: world.onRoomTag,
+	}, pkg.Trigger{
+		Kind:     pkg.Input,
+		Pattern:  []byte("room untag {*}"),
+		Callback: world.onRoomUntag,
+	}, pkg.Trigger{
+		Kind:     pkg.Input,
+		Pattern:  []byte("tagged {*}"),
+		Callback: world.onTagged,
+	}, pkg.Trigger{
+		Kind:     pkg.Input,
+		Pattern:  []byte("inv"),
+		Callback: world.onInv,
+	}, pkg.Trigger{
+		Kind:     pkg.Input,
+		Pattern:  []byte("skill info {^} {*}"),
+		Callback: world.onSkillInfo,
+	}, pkg.Trigger{
+		Kind:     pkg.Input,
+		Pattern:  []byte("outr {*}"),
+		Callback: world.onOutr,
+	})
+
+	for _, pattern := range walkFailures {
+		world.triggers = append(world.triggers, pkg.Trigger{
+			Kind:     pkg.Output,
+			Pattern:  pattern,
+			Callback: world.onWalkFailure,
+		})
+	}
+
 	return world
 }
 
+// Tasks exposes the results of background work, for the engine to apply.
+func (world *World) Tasks() <-chan func() {
+	return world.tasks
+}
+
+// AddTrigger adds a trigger to those run on input, output or GMCP messages.
+func (world *World) AddTrigger(trigger pkg.Trigger) {
+	world.triggers = append(world.triggers, trigger)
+}
+
 // OnInoutput reacts to player input and server output.
 func (world *World) OnInoutput(inout pkg.Inoutput) pkg.Inoutput {
 	// @todo Read the CommandSeparator configuration option and use that.
@@ -77,6 +212,8 @@ func (world *World) OnInoutput(inout pkg.Inoutput) pkg.Inoutput {
 		inout.Output = inout.Output.Omit(0)
 	}
 
+	inout = world.omitComms(inout)
+
 	for _, trigger := range world.triggers {
 		if trigger.Kind == pkg.Input && len(inout.Input) > 0 {
 			inout = trigger.Match(inout.Input.Bytes(), inout)
@@ -87,11 +224,135 @@ func (world *World) OnInoutput(inout pkg.Inoutput) pkg.Inoutput {
 		}
 	}
 
+	// Remember what was last done, to attribute balance recovery times.
+	if inputs := inout.Input.Bytes(); len(inputs) > 0 {
+		if fields := bytes.Fields(inputs[len(inputs)-1]); len(fields) > 0 {
+			world.Character.Action = string(fields[0])
+		}
+	}
+
 	// If only the prompt remains, we omit the whole paragraph.
 	// @todo Use above prompt detection instead of relying on lone lines
 	// being prompts.
 	if len(inout.Output.Bytes()) == 1 {
 		inout.Output = pkg.Exput{}
+
+		// Changes along with lone prompts are but regeneration.
+		world.deltas = nil
+	}
+
+	if len(inout.Output) > 0 {
+		inout = world.summarizeVitals(inout)
+	}
+
+	return inout
+}
+
+// How long communications wait for the paragraph they're shown in, before
+// they're forgotten, lest they hide unrelated lines later on.
+var commLifetime = 10 * time.Second
+
+// pendingComm is a communication waiting to be removed from the main output,
+// by its words, since the game may wrap it over several lines.
+type pendingComm struct {
+	words    [][]byte
+	received time.Time
+}
+
+// omitComms removes communications from the main output, which the player
+// has chosen to only see in the communications pane. They're sent by GMCP
+// around when they're shown, so they wait for a paragraph with all their
+// words on consecutive lines, until that or their lifetime runs out.
+func (world *World) omitComms(inout pkg.Inoutput) pkg.Inoutput {
+	if len(world.comms) == 0 || len(inout.Output) == 0 {
+		return inout
+	}
+
+	lines := make([][][]byte, len(inout.Output))
+	for i, line := range inout.Output {
+		lines[i] = bytes.Fields(line.Text.Clean())
+	}
+
+	pending := []pendingComm{}
+
+	for _, comm := range world.comms {
+		first, last, ok := coveringLines(lines, comm.words)
+		if !ok {
+			if time.Since(comm.received) < commLifetime {
+				pending = append(pending, comm)
+			}
+
+			continue
+		}
+
+		for i := first; i <= last; i++ {
+			inout.Output = inout.Output.Omit(i)
+
+			// Omitted lines can't be covered by another message.
+			lines[i] = nil
+		}
+	}
+
+	world.comms = pending
+
+	return inout
+}
+
+// coveringLines finds the consecutive lines whose words are exactly the given
+// ones, in order.
+func coveringLines(lines [][][]byte, words [][]byte) (int, int, bool) {
+	for first := range lines {
+		covered := 0
+
+		for last := first; last < len(lines) && len(lines[last]) > 0; last++ {
+			next := covered + len(lines[last])
+			if next > len(words) || !slices.EqualFunc(lines[last], words[covered:next], bytes.Equal) {
+				break
+			}
+
+			if covered = next; covered == len(words) {
+				return first, last, true
+			}
+		}
+	}
+
+	return 0, 0, false
+}
+
+// onBalances prints the history of balance and equilibrium recovery times, per
+// action, instead of sending the command to the game.
+func (world *World) onBalances(matches []pkg.Match, inout pkg.Inoutput) pkg.Inoutput {
+	for i := len(matches) - 1; i >= 0; i-- {
+		inout.Input = inout.Input.Omit(matches[i].Index)
+	}
+
+	recoveries := []struct {
+		name     string
+		recovery *Recovery
+	}{
+		{"balance", &world.Character.BalanceRecovery},
+		{"equilibrium", &world.Character.EquilibriumRecovery},
+	}
+
+	for _, r := range recoveries {
+		actions := maps.Keys(r.recovery.History)
+		sort.Strings(actions)
+
+		if len(actions) == 0 {
+			world.ui.Print([]byte(fmt.Sprintf("No %s recoveries recorded.", r.name)))
+			continue
+		}
+
+		for _, action := range actions {
+			history := r.recovery.History[action]
+
+			world.ui.Print([]byte(fmt.Sprintf(
+				"%s %s: %.2fs average over %d, last %.2fs",
+				r.name, action,
+				r.recovery.Average(action).Seconds(), len(history),
+				history[len(history)-1].Seconds(),
+			)))
+		}
 	}
 
 	return inout
@@ -102,11 +363,16 @@ func (world *World) OnInoutput(inout pkg.Inoutput) pkg.Inoutput {
 // only. Telnet commands are cool and all but YAGNI, evidently.
 func (world *World) OnCommand(cmd []byte) (inout pkg.Inoutput) {
 	if data := gmcp.Unwrap(cmd); data != nil {
-		err := world.onGMCP(data)
-		if err != nil {
+		if err := world.onGMCP(data); err != nil {
 			log.Printf("failed processing gmcp: %s", err)
 		}
 
+		for _, trigger := range world.triggers {
+			if trigger.Kind == pkg.GMCP {
+				inout = trigger.MatchGMCP(data, inout)
+			}
+		}
+
 		return
 	}
 
@@ -135,18 +401,70 @@ func (world *World) onGMCP(data []byte) error {
 	}
 
 	switch msg := message.(type) {
+	case *gmcp.RawMessage:
+		world.Unknown[msg.ID()] = msg
+
 	case *gmcp.CharItemsList:
 		world.Target.FromCharItemsList(msg)
 		world.ui.SetTarget(world.Target.PkgTarget())
 
+		world.Occupants.FromCharItemsList(msg)
+		world.setOccupants()
+
+		if err := world.requestContents(world.Inventory.FromCharItemsList(msg)); err != nil {
+			return err
+		}
+
 	case *gmcp.CharItemsAdd:
 		world.Target.FromCharItemsAdd(msg)
 		world.ui.SetTarget(world.Target.PkgTarget())
 
+		world.Occupants.FromCharItemsAdd(msg)
+		world.setOccupants()
+
+		if err := world.requestContents(world.Inventory.FromCharItemsAdd(msg)); err != nil {
+			return err
+		}
+
 	case *gmcp.CharItemsRemove:
 		world.Target.FromCharItemsRemove(msg)
 		world.ui.SetTarget(world.Target.PkgTarget())
 
+		world.Occupants.FromCharItemsRemove(msg)
+		world.setOccupants()
+
+		world.Inventory.FromCharItemsRemove(msg)
+
+	case *gmcp.CharItemsUpdate:
+		world.Occupants.FromCharItemsUpdate(msg)
+		world.setOccupants()
+
+		world.Inventory.FromCharItemsUpdate(msg)
+
+	case *gmcp.CharAfflictionsList:
+		world.Character.FromCharAfflictionsList(msg)
+		world.ui.SetCharacter(world.Character.PkgCharacter())
+
+	case *gmcp.CharAfflictionsAdd:
+		world.Character.FromCharAfflictionsAdd(msg)
+		world.ui.SetCharacter(world.Character.PkgCharacter())
+
+	case *gmcp.CharAfflictionsRemove:
+		world.Character.FromCharAfflictionsRemove(msg)
+		world.ui.SetCharacter(world.Character.PkgCharacter())
+
+	case *gmcp.CharDefencesList:
+		world.Character.FromCharDefencesList(msg)
+		world.ui.SetCharacter(world.Character.PkgCharacter())
+
+	case *gmcp.CharDefencesAdd:
+		world.Character.FromCharDefencesAdd(msg)
+		world.ui.SetCharacter(world.Character.PkgCharacter())
+
+	case *gmcp.CharDefencesRemove:
+		world.Character.FromCharDefencesRemove(msg)
+		world.ui.SetCharacter(world.Character.PkgCharacter())
+
 	case *gmcp.CharName:
 		world.Character.FromCharName(msg)
 
@@ -154,6 +472,7 @@ func (world *World) onGMCP(data []byte) error {
 			&gmcp.CharItemsInv{},
 			&gmcp.CommChannelPlayers{},
 			&igmcp.IRERiftRequest{},
+			&gmcp.CharSkillsGet{},
 		}
 		for _, msg := range msgs {
 			data := gmcp.Wrap([]byte(msg.ID()))
@@ -162,32 +481,119 @@ func (world *World) onGMCP(data []byte) error {
 			}
 		}
 
+	case *gmcp.CharSkillsGroups:
+		changed, unlisted := world.Skills.FromCharSkillsGroups(msg)
+
+		for _, group := range changed {
+			world.ui.Print([]byte(fmt.Sprintf(
+				"Your rank in %s is now %s.", group.Name, group.RankString(),
+			)))
+		}
+
+		for _, name := range unlisted {
+			if err := world.SendGMCP(&gmcp.CharSkillsGet{Group: name}); err != nil {
+				return fmt.Errorf("failed GMCP: %w", err)
+			}
+		}
+
+		world.ui.SetSkills(world.Skills.PkgSkills())
+
+	case *gmcp.CharSkillsList:
+		world.Skills.FromCharSkillsList(msg)
+		world.ui.SetSkills(world.Skills.PkgSkills())
+
+	case *gmcp.CharSkillsInfo:
+		world.Skills.FromCharSkillsInfo(msg)
+		world.ui.SetSkills(world.Skills.PkgSkills())
+
+		world.ui.Print([]byte(msg.Info))
+
+	case *gmcp.CommChannelPlayers:
+		world.Occupants.FromCommChannelPlayers(msg)
+		world.setOccupants()
+
+	case *gmcp.CommChannelText:
+		world.ui.AddCommunication(pkg.Communication{
+			Channel: msg.Channel,
+			Talker:  msg.Talker,
+			Text:    []byte(msg.Text),
+		})
+
+		words := bytes.Fields(pkg.Text(msg.Text).Clean())
+		if len(words) > 0 && !world.config.CommInMain(msg.Channel) {
+			world.comms = append(world.comms, pendingComm{
+				words:    words,
+				received: time.Now(),
+			})
+		}
+
 	case *agmcp.CharStatus:
+		previous := *world.Character
 		world.Character.FromCharStatus(msg)
 		world.ui.SetCharacter(world.Character.PkgCharacter())
 
+		if world.statusKnown {
+			world.notifyStatus(previous, *world.Character)
+		}
+
+		world.statusKnown = true
+
 		world.Target.FromCharStatus(msg)
 		world.ui.SetTarget(world.Target.PkgTarget())
 
 	case *agmcp.CharVitals:
+		previous := *world.Character
 		world.Character.FromCharVitals(msg)
+
+		if world.config.Vitals.Summary && previous.MaxHealth > 0 {
+			world.addVitalDeltas(previous, *world.Character)
+		}
+
 		world.ui.SetCharacter(world.Character.PkgCharacter())
 
 	case *gmcp.RoomInfo:
 		world.Target.FromRoomInfo(msg)
 		world.ui.SetTarget(world.Target.PkgTarget())
 
-		if world.Room != nil {
-			world.Room.HasPlayer = false
+		previous := world.Room
+		if previous != nil {
+			previous.HasPlayer = false
 		}
 
-		world.Room = navigation.RoomFromGMCP(msg)
+		world.Room = world.Map.RoomFromGMCP(msg)
 		world.Room.HasPlayer = true
 
+		world.ui.SetMap(world.Map)
 		world.ui.SetRoom(world.Room)
+		world.scheduleMapSave()
+
+		world.stepWalk(previous)
+
+	case *gmcp.RoomPlayers:
+		world.Occupants.FromRoomPlayers(msg)
+		world.setOccupants()
+
+	case *gmcp.RoomAddPlayer:
+		world.Occupants.FromRoomAddPlayer(msg)
+		world.setOccupants()
+
+	case *gmcp.RoomRemovePlayer:
+		world.Occupants.FromRoomRemovePlayer(msg)
+		world.setOccupants()
+
+	case *gmcp.ClientMap:
+		world.FromClientMap(msg)
+
+	case *igmcp.IRERiftList:
+		world.Rift.FromIRERiftList(msg)
+		world.ui.SetRift(world.Rift.PkgRift(world.config.Rift.Thresholds))
+
+	case *igmcp.IRERiftChange:
+		previous := world.Rift.Amount(msg.Name)
+		world.Rift.FromIRERiftChange(msg)
+		world.ui.SetRift(world.Rift.PkgRift(world.config.Rift.Thresholds))
 
-		// @todo Implement this to download the official map.
-		// case gmcp.ClientMap:
+		world.warnRift(msg.Name, previous, msg.Amount)
 
 	case *igmcp.IRETargetSet:
 		world.Target.FromIRETargetSet(msg)
@@ -201,6 +607,24 @@ func (world *World) onGMCP(data []byte) error {
 	return nil
 }
 
+// setOccupants shows who and what is in the room.
+func (world *World) setOccupants() {
+	world.ui.SetOccupants(world.Occupants.PkgOccupants(
+		world.Target.PkgTarget(), world.Character.Name,
+	))
+}
+
+// requestContents asks the game what's in the given containers.
+func (world *World) requestContents(containers []int) error {
+	for _, container := range containers {
+		if err := world.SendGMCP(&gmcp.CharItemsContents{Container: container}); err != nil {
+			return fmt.Errorf("failed GMCP: %w", err)
+		}
+	}
+
+	return nil
+}
+
 // SendGMCP writes a GMCP message to the client.
 func (world *World) SendGMCP(msg gmcp.Message) error {
 	data := gmcp.Wrap([]byte(msg.Marshal()))
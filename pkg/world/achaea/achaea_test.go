@@ -5,10 +5,12 @@ import (
 	"errors"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/tobiassjosten/nogfx/pkg"
 	"github.com/tobiassjosten/nogfx/pkg/gmcp"
 	"github.com/tobiassjosten/nogfx/pkg/mock"
+	"github.com/tobiassjosten/nogfx/pkg/navigation"
 	"github.com/tobiassjosten/nogfx/pkg/telnet"
 	tst "github.com/tobiassjosten/nogfx/pkg/testing"
 	"github.com/tobiassjosten/nogfx/pkg/world/achaea"
@@ -80,6 +82,7 @@ func TestInputOutput(t *testing.T) {
 			world, ok := achaea.NewWorld(
 				&mock.ClientMock{},
 				&mock.UIMock{},
+				pkg.NewConfig(),
 			).(*achaea.World)
 			require.True(t, ok)
 
@@ -127,7 +130,8 @@ func TestCommandsReply(t *testing.T) {
 			}),
 			sent: append(wrapGMCP("Char.Items.Inv", nil),
 				append(wrapGMCP("Comm.Channel.Players", nil),
-					wrapGMCP("IRE.Rift.Request", nil)...)...),
+					append(wrapGMCP("IRE.Rift.Request", nil),
+						wrapGMCP("Char.Skills.Get", nil)...)...)...),
 		},
 		{
 			command: wrapGMCP("Char.Name", map[int]int{}),
@@ -163,7 +167,7 @@ func TestCommandsReply(t *testing.T) {
 
 			ui := &mock.UIMock{}
 
-			world := achaea.NewWorld(client, ui)
+			world := achaea.NewWorld(client, ui, pkg.NewConfig())
 
 			world.OnCommand(tc.command)
 
@@ -206,6 +210,8 @@ func TestCommandsMutateWorld(t *testing.T) {
 				Title: "Mason Durak",
 				Class: "Monk",
 				Level: 68,
+
+				LevelProgress: 19,
 			},
 		},
 
@@ -265,7 +271,7 @@ func TestCommandsMutateWorld(t *testing.T) {
 				SetTargetFunc:    func(_ *pkg.Target) {},
 			}
 
-			world, ok := achaea.NewWorld(client, ui).(*achaea.World)
+			world, ok := achaea.NewWorld(client, ui, pkg.NewConfig()).(*achaea.World)
 			require.True(t, ok)
 
 			if len(tc.command) > 0 {
@@ -323,6 +329,10 @@ func TestCommandsMutateVitals(t *testing.T) {
 					"kai":       {Value: 4, Max: 100},
 					"karma":     {Value: 5, Max: 100},
 				},
+				Balances: map[string]pkg.CharacterBalance{
+					"balance":     {Has: true},
+					"equilibrium": {Has: true},
+				},
 			},
 		},
 	}
@@ -336,7 +346,7 @@ func TestCommandsMutateVitals(t *testing.T) {
 				},
 			}
 
-			world := achaea.NewWorld(&mock.ClientMock{}, ui)
+			world := achaea.NewWorld(&mock.ClientMock{}, ui, pkg.NewConfig())
 
 			world.OnCommand(tc.command)
 
@@ -344,3 +354,346 @@ func TestCommandsMutateVitals(t *testing.T) {
 		})
 	}
 }
+
+func TestCommunications(t *testing.T) {
+	tcs := map[string]struct {
+		main     []string
+		lifetime time.Duration
+		comms    []gmcp.CommChannelText
+		events   []tst.IOEvent
+		inouts   []pkg.Inoutput
+		channels []string
+	}{
+		"kept in main": {
+			main: []string{"*"},
+			comms: []gmcp.CommChannelText{
+				{Channel: "ct", Talker: "Durak", Text: "\033[36m(Ashtan): Durak says, \"Hi.\""},
+			},
+			events: []tst.IOEvent{
+				tst.IOEOuts([]string{
+					"(Ashtan): Durak says, \"Hi.\"",
+					"123h 234m\0371",
+				}),
+			},
+			inouts: []pkg.Inoutput{
+				tst.IOOuts([]string{
+					"(Ashtan): Durak says, \"Hi.\"",
+					"123h 234m\0371",
+				}),
+			},
+			channels: []string{"ct"},
+		},
+
+		"omitted from main": {
+			main: []string{"ct"},
+			comms: []gmcp.CommChannelText{
+				{Channel: "tell Durak", Talker: "Durak", Text: "\033[33mDurak tells you, \"Hi.\"\033[0m"},
+				{Channel: "ct", Talker: "Durak", Text: "(Ashtan): Durak says, \"Hi.\""},
+			},
+			events: []tst.IOEvent{
+				tst.IOEOuts([]string{
+					"\033[33mDurak tells you, \"Hi.\"\033[0m",
+					"(Ashtan): Durak says, \"Hi.\"",
+					"123h 234m\0371",
+				}),
+				tst.IOEOuts([]string{
+					"Durak tells you, \"Hi.\"",
+					"123h 234m\0371",
+				}),
+			},
+			inouts: []pkg.Inoutput{
+				tst.IOOuts([]string{
+					"\033[33mDurak tells you, \"Hi.\"\033[0m",
+					"(Ashtan): Durak says, \"Hi.\"",
+					"123h 234m\0371",
+				}).OmitOutput(0),
+				tst.IOOuts([]string{
+					"Durak tells you, \"Hi.\"",
+					"123h 234m\0371",
+				}),
+			},
+			channels: []string{"tell Durak", "ct"},
+		},
+
+		"wrapped over lines": {
+			main: []string{},
+			comms: []gmcp.CommChannelText{
+				{Channel: "tell Durak", Talker: "Durak", Text: "Durak tells you, \"Hi there.\""},
+			},
+			events: []tst.IOEvent{
+				tst.IOEOuts([]string{
+					"You see Durak.",
+					"Durak tells you, \"Hi",
+					"there.\"",
+					"123h 234m\0371",
+				}),
+			},
+			inouts: []pkg.Inoutput{
+				tst.IOOuts([]string{
+					"You see Durak.",
+					"Durak tells you, \"Hi",
+					"there.\"",
+					"123h 234m\0371",
+				}).OmitOutput(1).OmitOutput(2),
+			},
+			channels: []string{"tell Durak"},
+		},
+
+		"waiting for its paragraph": {
+			main: []string{},
+			comms: []gmcp.CommChannelText{
+				{Channel: "tell Durak", Talker: "Durak", Text: "Durak tells you, \"Hi.\""},
+			},
+			events: []tst.IOEvent{
+				tst.IOEOuts([]string{
+					"You see Durak.",
+					"123h 234m\0371",
+				}),
+				tst.IOEOuts([]string{
+					"Durak tells you, \"Hi.\"",
+					"Durak smiles.",
+					"123h 234m\0371",
+				}),
+			},
+			inouts: []pkg.Inoutput{
+				tst.IOOuts([]string{
+					"You see Durak.",
+					"123h 234m\0371",
+				}),
+				tst.IOOuts([]string{
+					"Durak tells you, \"Hi.\"",
+					"Durak smiles.",
+					"123h 234m\0371",
+				}).OmitOutput(0),
+			},
+			channels: []string{"tell Durak"},
+		},
+
+		"forgotten in time": {
+			main:     []string{},
+			lifetime: time.Nanosecond,
+			comms: []gmcp.CommChannelText{
+				{Channel: "tell Durak", Talker: "Durak", Text: "Durak tells you, \"Hi.\""},
+			},
+			events: []tst.IOEvent{
+				tst.IOEOuts([]string{
+					"You see Durak.",
+					"123h 234m\0371",
+				}),
+				tst.IOEOuts([]string{
+					"Durak tells you, \"Hi.\"",
+					"123h 234m\0371",
+				}),
+			},
+			inouts: []pkg.Inoutput{
+				tst.IOOuts([]string{
+					"You see Durak.",
+					"123h 234m\0371",
+				}),
+				tst.IOOuts([]string{
+					"Durak tells you, \"Hi.\"",
+					"123h 234m\0371",
+				}),
+			},
+			channels: []string{"tell Durak"},
+		},
+	}
+
+	for name, tc := range tcs {
+		t.Run(name, func(t *testing.T) {
+			if tc.lifetime > 0 {
+				defer achaea.SetCommLifetime(tc.lifetime)()
+			}
+
+			var channels []string
+
+			ui := &mock.UIMock{
+				AddCommunicationFunc: func(comm pkg.Communication) {
+					channels = append(channels, comm.Channel)
+				},
+			}
+
+			config := pkg.NewConfig()
+			config.Comm.Main = tc.main
+
+			world := achaea.NewWorld(&mock.ClientMock{}, ui, config)
+
+			for _, comm := range tc.comms {
+				world.OnCommand(wrapGMCP(comm.Marshal(), nil))
+			}
+
+			var inouts []pkg.Inoutput
+			for _, event := range tc.events {
+				inouts = append(inouts, world.OnInoutput(event.Inoutput()))
+			}
+
+			assert.Equal(t, tc.inouts, inouts)
+			assert.Equal(t, tc.channels, channels)
+		})
+	}
+}
+
+func TestBalances(t *testing.T) {
+	var prints []string
+
+	ui := &mock.UIMock{
+		PrintFunc: func(data []byte) {
+			prints = append(prints, string(data))
+		},
+		SetCharacterFunc: func(_ pkg.Character) {},
+	}
+
+	world, ok := achaea.NewWorld(&mock.ClientMock{}, ui, pkg.NewConfig()).(*achaea.World)
+	require.True(t, ok)
+
+	inout := world.OnInoutput(pkg.NewInoutput([][]byte{[]byte("kick rat")}, nil))
+	assert.Equal(t, [][]byte{[]byte("kick rat")}, inout.Input.Bytes())
+	assert.Equal(t, "kick", world.Character.Action)
+
+	world.Character.BalanceRecovery.History = map[string][]time.Duration{
+		"kick": {2 * time.Second, 3 * time.Second},
+	}
+
+	inout = world.OnInoutput(pkg.NewInoutput([][]byte{[]byte("balances")}, nil))
+	assert.Empty(t, inout.Input.Bytes())
+	assert.Equal(t, "kick", world.Character.Action)
+
+	assert.Equal(t, []string{
+		"balance kick: 2.50s average over 2, last 3.00s",
+		"No equilibrium recoveries recorded.",
+	}, prints)
+}
+
+func TestVitalsSummary(t *testing.T) {
+	vitals := func(hp, mp string) []byte {
+		return wrapGMCP("Char.Vitals", map[string]any{
+			"hp": hp, "maxhp": "3905",
+			"mp": mp, "maxmp": "3846",
+			"charstats": []string{},
+		})
+	}
+
+	config := pkg.NewConfig()
+	config.Vitals.Summary = true
+
+	ui := &mock.UIMock{
+		SetCharacterFunc: func(_ pkg.Character) {},
+	}
+
+	world := achaea.NewWorld(&mock.ClientMock{}, ui, config)
+
+	world.OnCommand(vitals("3905", "3846"))
+	world.OnCommand(vitals("3500", "3846"))
+	world.OnCommand(vitals("3473", "3900"))
+
+	inout := world.OnInoutput(pkg.NewInoutput(nil, [][]byte{
+		[]byte("Durak kicks you."),
+		[]byte("3473h, 3900m"),
+	}))
+
+	assert.Equal(t, [][]byte{
+		[]byte("Durak kicks you."),
+		[]byte("\033[31m-432 hp\033[0m, \033[32m+54 mp\033[0m"),
+		[]byte("3473h, 3900m"),
+	}, inout.Output.Bytes())
+
+	// Changes along with lone prompts are discarded as regeneration.
+	world.OnCommand(vitals("3500", "3900"))
+	world.OnInoutput(pkg.NewInoutput(nil, [][]byte{[]byte("3500h, 3900m")}))
+
+	inout = world.OnInoutput(pkg.NewInoutput(nil, [][]byte{
+		[]byte("Durak smiles."),
+		[]byte("3500h, 3900m"),
+	}))
+
+	assert.Equal(t, [][]byte{
+		[]byte("Durak smiles."),
+		[]byte("3500h, 3900m"),
+	}, inout.Output.Bytes())
+}
+
+func TestMapPersistence(t *testing.T) {
+	roomInfo := func(num int, exits map[string]int) []byte {
+		return wrapGMCP("Room.Info", map[string]any{
+			"num":    num,
+			"name":   fmt.Sprintf("Room %d", num),
+			"area":   "Hashan",
+			"coords": "12,1,2",
+			"exits":  exits,
+		})
+	}
+
+	config := pkg.NewConfig()
+	config.Dir = t.TempDir()
+
+	ui := &mock.UIMock{
+		SetMapFunc:    func(_ *navigation.Map) {},
+		SetRoomFunc:   func(_ *navigation.Room) {},
+		SetTargetFunc: func(_ *pkg.Target) {},
+	}
+
+	world, ok := achaea.NewWorld(&mock.ClientMock{}, ui, config).(*achaea.World)
+	require.True(t, ok)
+
+	defer achaea.SetMapSaveDelay(time.Millisecond)()
+
+	world.OnCommand(roomInfo(1, map[string]int{"n": 2}))
+	world.OnCommand(roomInfo(2, map[string]int{"s": 1}))
+
+	// Changes are gathered and saved later, by a single task.
+	assert.True(t, world.Map.Modified())
+
+	task := <-world.Tasks()
+	task()
+
+	assert.False(t, world.Map.Modified())
+
+	world, ok = achaea.NewWorld(&mock.ClientMock{}, ui, config).(*achaea.World)
+	require.True(t, ok)
+
+	require.Contains(t, world.Map.Rooms, 1)
+	assert.Equal(t, "Room 1", world.Map.Rooms[1].Name)
+	assert.True(t, world.Map.Rooms[1].HasExit("n s"))
+	assert.Equal(t, "Hashan", world.Map.Rooms[2].Area.Name)
+}
+
+func TestUnknownGMCP(t *testing.T) {
+	world, ok := achaea.NewWorld(&mock.ClientMock{}, &mock.UIMock{}, pkg.NewConfig()).(*achaea.World)
+	require.True(t, ok)
+
+	world.OnCommand(wrapGMCP("Non.Existant", map[string]int{"a": 1}))
+
+	assert.Equal(t, map[string]*gmcp.RawMessage{
+		"Non.Existant": {
+			MessageID: "Non.Existant",
+			Data:      map[string]any{"a": float64(1)},
+		},
+	}, world.Unknown)
+}
+
+func TestGMCPTriggers(t *testing.T) {
+	world, ok := achaea.NewWorld(&mock.ClientMock{}, &mock.UIMock{}, pkg.NewConfig()).(*achaea.World)
+	require.True(t, ok)
+
+	var matches []pkg.Match
+
+	world.AddTrigger(pkg.Trigger{
+		Kind:    pkg.GMCP,
+		Pattern: []byte("Non.{*}"),
+		Callback: func(ms []pkg.Match, inout pkg.Inoutput) pkg.Inoutput {
+			matches = append(matches, ms...)
+			inout.Output = inout.Output.Add(ms[0].Data)
+			return inout
+		},
+	})
+
+	inout := world.OnCommand(wrapGMCP("Non.Existant", map[string]int{"a": 1}))
+	world.OnCommand(wrapGMCP("Other.Existant", map[string]int{"a": 1}))
+
+	assert.Equal(t, []pkg.Match{{
+		Kind:     pkg.GMCP,
+		Captures: [][]byte{[]byte("Existant")},
+		Data:     []byte(`{"a":1}`),
+	}}, matches)
+	assert.Equal(t, [][]byte{[]byte(`{"a":1}`)}, inout.Output.Bytes())
+}
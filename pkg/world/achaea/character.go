@@ -1,9 +1,16 @@
 package achaea
 
 import (
+	"fmt"
+	"math"
+	"strconv"
+	"time"
+
 	"github.com/tobiassjosten/nogfx/pkg"
 	"github.com/tobiassjosten/nogfx/pkg/gmcp"
 	agmcp "github.com/tobiassjosten/nogfx/pkg/gmcp/achaea"
+
+	"golang.org/x/exp/slices"
 )
 
 // Character is the currently logged in character.
@@ -11,14 +18,49 @@ type Character struct {
 	Name  string
 	Title string
 
-	Class string
+	Race           string
+	Gender         string
+	Age            int
+	Class          string
+	Specialisation string
 
 	Level int
 	XP    int
 
+	// LevelProgress is the percentage towards the next level.
+	LevelProgress float64
+
+	XPRank       int
+	ExplorerRank string
+
+	City      string
+	CityRank  int
+	House     string
+	HouseRank int
+	Order     string
+	OrderRank int
+
+	Gold             int
+	Bank             int
+	Lessons          int
+	MayanCrowns      int
+	BoundMayanCrowns int
+	BoundCredits     int
+	UnboundCredits   int
+
+	UnreadNews     int
+	UnreadMessages int
+
 	Balance     bool
 	Equilibrium bool
 
+	BalanceRecovery     Recovery
+	EquilibriumRecovery Recovery
+
+	// Action is the most recent command sent to the game, to know what
+	// spent balance or equilibrium.
+	Action string
+
 	Health       int
 	MaxHealth    int
 	Mana         int
@@ -36,6 +78,12 @@ type Character struct {
 	Karma    int
 	Spec     string
 	Stance   string
+
+	Afflictions []pkg.CharacterAffliction
+	Defences    []string
+
+	// Keepup lists defences the player wants to always have up.
+	Keepup []string
 }
 
 // PkgCharacter converts our game-specific Character to the general pkg struct.
@@ -47,6 +95,27 @@ func (c *Character) PkgCharacter() pkg.Character {
 			"endurance": {Value: c.Endurance, Max: c.MaxEndurance},
 			"willpower": {Value: c.Willpower, Max: c.MaxWillpower},
 		},
+		Balances: map[string]pkg.CharacterBalance{
+			"balance": {
+				Has:  c.Balance,
+				Last: c.BalanceRecovery.Last,
+			},
+			"equilibrium": {
+				Has:  c.Equilibrium,
+				Last: c.EquilibriumRecovery.Last,
+			},
+		},
+	}
+
+	pc.Sheet = c.sheet()
+
+	pc.Afflictions = append(pc.Afflictions, c.Afflictions...)
+	pc.Defences = append(pc.Defences, c.Defences...)
+
+	for _, name := range c.Keepup {
+		if !slices.Contains(c.Defences, name) {
+			pc.MissingDefences = append(pc.MissingDefences, name)
+		}
 	}
 
 	// @todo Implement a way to differentiate between having 0 of the below
@@ -82,23 +151,143 @@ func (c *Character) FromCharName(msg *gmcp.CharName) {
 	c.Title = msg.Fullname
 }
 
-// FromCharStatus updates the character from a Char.Status GMCP message.
+// sheet lists the details worth showing about the character, leaving out
+// those which are unknown or empty.
+func (c *Character) sheet() []pkg.CharacterDetail {
+	details := []pkg.CharacterDetail{}
+
+	add := func(name, value string) {
+		if value != "" {
+			details = append(details, pkg.CharacterDetail{Name: name, Value: value})
+		}
+	}
+
+	number := func(value int) string {
+		if value == 0 {
+			return ""
+		}
+
+		return strconv.Itoa(value)
+	}
+
+	ranked := func(name string, rank int) string {
+		if name == "" || rank == 0 {
+			return name
+		}
+
+		return fmt.Sprintf("%s (%d)", name, rank)
+	}
+
+	title := c.Title
+	if title == "" {
+		title = c.Name
+	}
+
+	add("Name", title)
+	add("Race", c.Race)
+	add("Gender", c.Gender)
+	add("Age", number(c.Age))
+
+	class := c.Class
+	if class != "" && c.Specialisation != "" {
+		class = fmt.Sprintf("%s (%s)", class, c.Specialisation)
+	}
+
+	add("Class", class)
+
+	if c.Level > 0 {
+		add("Level", fmt.Sprintf("%d (%g%%)", c.Level, c.LevelProgress))
+	}
+
+	if c.XPRank > 0 {
+		add("XP rank", fmt.Sprintf("#%d", c.XPRank))
+	}
+
+	add("Explorer", c.ExplorerRank)
+	add("City", ranked(c.City, c.CityRank))
+	add("House", ranked(c.House, c.HouseRank))
+	add("Order", ranked(c.Order, c.OrderRank))
+	add("Gold", number(c.Gold))
+	add("Bank", number(c.Bank))
+	add("Lessons", number(c.Lessons))
+
+	if credits := c.BoundCredits + c.UnboundCredits; credits > 0 {
+		add("Credits", fmt.Sprintf("%d (%d bound)", credits, c.BoundCredits))
+	}
+
+	if crowns := c.MayanCrowns + c.BoundMayanCrowns; crowns > 0 {
+		add("Mayan crowns", fmt.Sprintf("%d (%d bound)", crowns, c.BoundMayanCrowns))
+	}
+
+	add("Unread news", number(c.UnreadNews))
+	add("Unread messages", number(c.UnreadMessages))
+
+	if len(details) == 0 {
+		return nil
+	}
+
+	return details
+}
+
+// FromCharStatus updates the character from a Char.Status GMCP message. Only
+// the first message carries all values, so those left out are kept as is.
 func (c *Character) FromCharStatus(msg *agmcp.CharStatus) {
-	if msg.Name != nil {
-		c.Name = *msg.Name
+	setString := func(field *string, value *string) {
+		if value != nil {
+			*field = *value
+		}
 	}
 
-	if msg.Fullname != nil {
-		c.Title = *msg.Fullname
+	setInt := func(field *int, value *int) {
+		if value != nil {
+			*field = *value
+		}
 	}
 
-	if msg.Class != nil {
-		c.Class = *msg.Class
+	setRanked := func(field *string, rankField *int, value *string, rank *int) {
+		if value == nil {
+			return
+		}
+
+		*field = *value
+		*rankField = 0
+
+		if rank != nil {
+			*rankField = *rank
+		}
 	}
 
+	setString(&c.Name, msg.Name)
+	setString(&c.Title, msg.Fullname)
+	setString(&c.Race, msg.Race)
+	setString(&c.Gender, msg.Gender)
+	setInt(&c.Age, msg.Age)
+	setString(&c.Class, msg.Class)
+	setString(&c.Specialisation, msg.Specialisation)
+
 	if msg.Level != nil {
-		c.Level = int(*msg.Level)
+		level := math.Floor(*msg.Level)
+		c.Level = int(level)
+		c.LevelProgress = math.Round((*msg.Level-level)*10000) / 100
 	}
+
+	setInt(&c.XPRank, msg.XPRank)
+	setString(&c.ExplorerRank, msg.ExplorerRank)
+
+	setRanked(&c.City, &c.CityRank, msg.City, msg.CityRank)
+	setRanked(&c.House, &c.HouseRank, msg.House, msg.HouseRank)
+	setRanked(&c.Order, &c.OrderRank, msg.Order, msg.OrderRank)
+
+	setInt(&c.Gold, msg.Gold)
+	setInt(&c.Bank, msg.Bank)
+	setInt(&c.Lessons, msg.Lessons)
+	setInt(&c.MayanCrowns, msg.MayanCrowns)
+	setInt(&c.BoundMayanCrowns, msg.BoundMayanCrowns)
+	setInt(&c.BoundCredits, msg.BoundCredits)
+	setInt(&c.UnboundCredits, msg.UnboundCredits)
+
+	setInt(&c.UnreadNews, msg.UnreadNews)
+	setInt(&c.UnreadMessages, msg.UnreadMsgs)
 }
 
 // FromCharVitals updates the character from a Char.Vitals GMCP message.
@@ -108,6 +297,10 @@ func (c *Character) FromCharVitals(msg *agmcp.CharVitals) {
 	c.Balance = msg.Bal
 	c.Equilibrium = msg.Eq
 
+	now := time.Now()
+	c.BalanceRecovery.Update(msg.Bal, c.Action, now)
+	c.EquilibriumRecovery.Update(msg.Eq, c.Action, now)
+
 	c.Health = msg.HP
 	c.MaxHealth = msg.MaxHP
 	c.Mana = msg.MP
@@ -140,3 +333,86 @@ func (c *Character) FromCharVitals(msg *agmcp.CharVitals) {
 		c.Stance = *msg.Stats.Stance
 	}
 }
+
+// FromCharAfflictionsList updates the character from a Char.Afflictions.List
+// GMCP message.
+func (c *Character) FromCharAfflictionsList(msg *gmcp.CharAfflictionsList) {
+	c.Afflictions = nil
+
+	for _, affliction := range *msg {
+		c.addAffliction(affliction)
+	}
+}
+
+// FromCharAfflictionsAdd updates the character from a Char.Afflictions.Add
+// GMCP message.
+func (c *Character) FromCharAfflictionsAdd(msg *gmcp.CharAfflictionsAdd) {
+	c.addAffliction(gmcp.CharAffliction(*msg))
+}
+
+// FromCharAfflictionsRemove updates the character from a
+// Char.Afflictions.Remove GMCP message.
+func (c *Character) FromCharAfflictionsRemove(msg *gmcp.CharAfflictionsRemove) {
+	for _, affliction := range *msg {
+		if i := c.afflictionIndex(affliction.Name); i >= 0 {
+			c.Afflictions = slices.Delete(c.Afflictions, i, i+1)
+		}
+	}
+
+	if len(c.Afflictions) == 0 {
+		c.Afflictions = nil
+	}
+}
+
+func (c *Character) addAffliction(affliction gmcp.CharAffliction) {
+	if c.afflictionIndex(affliction.Name) >= 0 {
+		return
+	}
+
+	c.Afflictions = append(c.Afflictions, pkg.CharacterAffliction{
+		Name: affliction.Name,
+		Cure: affliction.Cure,
+	})
+}
+
+func (c *Character) afflictionIndex(name string) int {
+	return slices.IndexFunc(c.Afflictions, func(a pkg.CharacterAffliction) bool {
+		return a.Name == name
+	})
+}
+
+// FromCharDefencesList updates the character from a Char.Defences.List GMCP
+// message.
+func (c *Character) FromCharDefencesList(msg *gmcp.CharDefencesList) {
+	c.Defences = nil
+
+	for _, defence := range *msg {
+		c.addDefence(defence.Name)
+	}
+}
+
+// FromCharDefencesAdd updates the character from a Char.Defences.Add GMCP
+// message.
+func (c *Character) FromCharDefencesAdd(msg *gmcp.CharDefencesAdd) {
+	c.addDefence(msg.Name)
+}
+
+// FromCharDefencesRemove updates the character from a Char.Defences.Remove
+// GMCP message.
+func (c *Character) FromCharDefencesRemove(msg *gmcp.CharDefencesRemove) {
+	for _, defence := range *msg {
+		if i := slices.Index(c.Defences, defence.Name); i >= 0 {
+			c.Defences = slices.Delete(c.Defences, i, i+1)
+		}
+	}
+
+	if len(c.Defences) == 0 {
+		c.Defences = nil
+	}
+}
+
+func (c *Character) addDefence(name string) {
+	if !slices.Contains(c.Defences, name) {
+		c.Defences = append(c.Defences, name)
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"testing"
 
+	"github.com/tobiassjosten/nogfx/pkg"
 	"github.com/tobiassjosten/nogfx/pkg/gmcp"
 	agmcp "github.com/tobiassjosten/nogfx/pkg/gmcp/achaea"
 	"github.com/tobiassjosten/nogfx/pkg/world/achaea"
@@ -46,6 +47,40 @@ func TestFromGMCP(t *testing.T) {
 			},
 		},
 
+		{
+			in: &achaea.Character{
+				Name:      "Durak",
+				Class:     "Monk",
+				Gold:      100,
+				City:      "Hashan",
+				CityRank:  3,
+				House:     "Shadowdancers",
+				HouseRank: 2,
+			},
+			message: &agmcp.CharStatus{
+				Gold:       gox.NewInt(150),
+				Lessons:    gox.NewInt(20),
+				Level:      gox.NewFloat64(68.19),
+				City:       gox.NewString(""),
+				House:      gox.NewString("Shadowdancers"),
+				HouseRank:  gox.NewInt(4),
+				UnreadMsgs: gox.NewInt(2),
+			},
+			out: &achaea.Character{
+				Name:  "Durak",
+				Class: "Monk",
+				Gold:  150,
+
+				Level:         68,
+				LevelProgress: 19,
+
+				Lessons:        20,
+				House:          "Shadowdancers",
+				HouseRank:      4,
+				UnreadMessages: 2,
+			},
+		},
+
 		{
 			in: &achaea.Character{},
 			message: &agmcp.CharVitals{
@@ -96,10 +131,135 @@ func TestFromGMCP(t *testing.T) {
 				Stance:   "Scorpion",
 			},
 		},
+
+		{
+			in: &achaea.Character{
+				Afflictions: []pkg.CharacterAffliction{{Name: "asthma"}},
+			},
+			message: &gmcp.CharAfflictionsList{
+				{Name: "paralysis", Cure: "eat bloodroot"},
+				{Name: "clumsiness", Cure: "eat kelp"},
+			},
+			out: &achaea.Character{
+				Afflictions: []pkg.CharacterAffliction{
+					{Name: "paralysis", Cure: "eat bloodroot"},
+					{Name: "clumsiness", Cure: "eat kelp"},
+				},
+			},
+		},
+
+		{
+			in: &achaea.Character{
+				Afflictions: []pkg.CharacterAffliction{
+					{Name: "paralysis", Cure: "eat bloodroot"},
+				},
+			},
+			message: &gmcp.CharAfflictionsAdd{
+				Name: "paralysis", Cure: "eat bloodroot",
+			},
+			out: &achaea.Character{
+				Afflictions: []pkg.CharacterAffliction{
+					{Name: "paralysis", Cure: "eat bloodroot"},
+				},
+			},
+		},
+
+		{
+			in: &achaea.Character{},
+			message: &gmcp.CharAfflictionsAdd{
+				Name: "asthma", Cure: "eat kelp",
+			},
+			out: &achaea.Character{
+				Afflictions: []pkg.CharacterAffliction{
+					{Name: "asthma", Cure: "eat kelp"},
+				},
+			},
+		},
+
+		{
+			in: &achaea.Character{
+				Afflictions: []pkg.CharacterAffliction{
+					{Name: "paralysis", Cure: "eat bloodroot"},
+					{Name: "asthma", Cure: "eat kelp"},
+				},
+			},
+			message: &gmcp.CharAfflictionsRemove{{Name: "paralysis"}},
+			out: &achaea.Character{
+				Afflictions: []pkg.CharacterAffliction{
+					{Name: "asthma", Cure: "eat kelp"},
+				},
+			},
+		},
+
+		{
+			in: &achaea.Character{
+				Afflictions: []pkg.CharacterAffliction{{Name: "asthma"}},
+			},
+			message: &gmcp.CharAfflictionsRemove{{Name: "asthma"}},
+			out:     &achaea.Character{},
+		},
+
+		{
+			in: &achaea.Character{Defences: []string{"blindness"}},
+			message: &gmcp.CharDefencesList{
+				{Name: "deafness"}, {Name: "insomnia"},
+			},
+			out: &achaea.Character{
+				Defences: []string{"deafness", "insomnia"},
+			},
+		},
+
+		{
+			in:      &achaea.Character{Defences: []string{"deafness"}},
+			message: &gmcp.CharDefencesAdd{Name: "insomnia"},
+			out: &achaea.Character{
+				Defences: []string{"deafness", "insomnia"},
+			},
+		},
+
+		{
+			in:      &achaea.Character{Defences: []string{"deafness"}},
+			message: &gmcp.CharDefencesAdd{Name: "deafness"},
+			out:     &achaea.Character{Defences: []string{"deafness"}},
+		},
+
+		{
+			in: &achaea.Character{
+				Defences: []string{"deafness", "insomnia"},
+			},
+			message: &gmcp.CharDefencesRemove{{Name: "deafness"}},
+			out:     &achaea.Character{Defences: []string{"insomnia"}},
+		},
+
+		{
+			in:      &achaea.Character{Defences: []string{"deafness"}},
+			message: &gmcp.CharDefencesRemove{{Name: "deafness"}},
+			out:     &achaea.Character{},
+		},
 	}
 
 	for i, tc := range tcs {
 		t.Run(fmt.Sprintf("case %d", i), func(t *testing.T) {
+			switch msg := tc.message.(type) {
+			case *gmcp.CharAfflictionsList:
+				tc.in.FromCharAfflictionsList(msg)
+
+			case *gmcp.CharAfflictionsAdd:
+				tc.in.FromCharAfflictionsAdd(msg)
+
+			case *gmcp.CharAfflictionsRemove:
+				tc.in.FromCharAfflictionsRemove(msg)
+
+			case *gmcp.CharDefencesList:
+				tc.in.FromCharDefencesList(msg)
+
+			case *gmcp.CharDefencesAdd:
+				tc.in.FromCharDefencesAdd(msg)
+
+			case *gmcp.CharDefencesRemove:
+				tc.in.FromCharDefencesRemove(msg)
+			}
+
 			if msg, ok := tc.message.(*gmcp.CharName); ok {
 				tc.in.FromCharName(msg)
 			}
@@ -116,3 +276,54 @@ func TestFromGMCP(t *testing.T) {
 		})
 	}
 }
+
+func TestPkgCharacterDefences(t *testing.T) {
+	character := &achaea.Character{
+		Afflictions: []pkg.CharacterAffliction{
+			{Name: "asthma", Cure: "eat kelp"},
+		},
+		Defences: []string{"deafness", "nightsight"},
+		Keepup:   []string{"deafness", "insomnia"},
+	}
+
+	pc := character.PkgCharacter()
+
+	assert.Equal(t, character.Afflictions, pc.Afflictions)
+	assert.Equal(t, []string{"deafness", "nightsight"}, pc.Defences)
+	assert.Equal(t, []string{"insomnia"}, pc.MissingDefences)
+}
+
+func TestPkgCharacterSheet(t *testing.T) {
+	character := &achaea.Character{
+		Name:           "Durak",
+		Title:          "Mason Durak",
+		Race:           "Human",
+		Class:          "Monk",
+		Specialisation: "Shikudo",
+		Level:          68,
+		LevelProgress:  19.5,
+		XPRank:         123,
+		City:           "Hashan",
+		CityRank:       3,
+		Order:          "Sarapis",
+		Gold:           150,
+		BoundCredits:   10,
+		UnboundCredits: 5,
+		UnreadNews:     4,
+	}
+
+	assert.Equal(t, []pkg.CharacterDetail{
+		{Name: "Name", Value: "Mason Durak"},
+		{Name: "Race", Value: "Human"},
+		{Name: "Class", Value: "Monk (Shikudo)"},
+		{Name: "Level", Value: "68 (19.5%)"},
+		{Name: "XP rank", Value: "#123"},
+		{Name: "City", Value: "Hashan (3)"},
+		{Name: "Order", Value: "Sarapis"},
+		{Name: "Gold", Value: "150"},
+		{Name: "Credits", Value: "15 (10 bound)"},
+		{Name: "Unread news", Value: "4"},
+	}, character.PkgCharacter().Sheet)
+
+	assert.Nil(t, (&achaea.Character{}).PkgCharacter().Sheet)
+}
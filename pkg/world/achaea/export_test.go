@@ -0,0 +1,25 @@
+package achaea
+
+import "time"
+
+// SetMapSaveDelay changes how long changes to the map are gathered before it's
+// saved, returning a function restoring the previous delay.
+func SetMapSaveDelay(delay time.Duration) func() {
+	previous := mapSaveDelay
+	mapSaveDelay = delay
+
+	return func() {
+		mapSaveDelay = previous
+	}
+}
+
+// SetCommLifetime changes how long communications wait to be omitted from the
+// main output, returning a function restoring the previous lifetime.
+func SetCommLifetime(lifetime time.Duration) func() {
+	previous := commLifetime
+	commLifetime = lifetime
+
+	return func() {
+		commLifetime = previous
+	}
+}
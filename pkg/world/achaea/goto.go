@@ -0,0 +1,121 @@
+package achaea
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/tobiassjosten/nogfx/pkg"
+	"github.com/tobiassjosten/nogfx/pkg/navigation"
+)
+
+// Messages from the game when a move fails, which stops a walk.
+var walkFailures = [][]byte{
+	[]byte("There is no exit in that direction."),
+	[]byte("There is a door in the way."),
+	[]byte("The door is locked."),
+	[]byte("You cannot move that fast, slow down!"),
+	[]byte("You are unable to move."),
+	[]byte("* blocks your way."),
+}
+
+// Goto walks the path to the given destination, step by step, as the game
+// confirms each move. It returns the first move to make, or false if there's
+// no way there.
+func (world *World) Goto(destination string) ([]byte, bool) {
+	world.walk = nil
+
+	if world.Room == nil {
+		world.ui.Print([]byte("Can't find a path without knowing where you are."))
+		return nil, false
+	}
+
+	goal, ok := world.Map.Destination(destination)
+	if !ok {
+		world.ui.Print([]byte(fmt.Sprintf("Can't find '%s' on the map.", destination)))
+		return nil, false
+	}
+
+	if goal(world.Room) {
+		world.ui.Print([]byte("You're already there."))
+		return nil, false
+	}
+
+	steps, ok := navigation.Path(world.Room, goal, navigation.PathOptions{
+		Weights:           world.config.Navigation.Weights,
+		AvoidRooms:        world.config.Navigation.Avoid.Rooms,
+		AvoidEnvironments: world.config.Navigation.Avoid.Environments,
+	})
+	if !ok {
+		world.ui.Print([]byte(fmt.Sprintf("Can't find a path to '%s'.", destination)))
+		return nil, false
+	}
+
+	world.walk = steps
+
+	return []byte(steps[0].Direction), true
+}
+
+// onGoto replaces the goto command with the first move towards the given
+// destination.
+func (world *World) onGoto(matches []pkg.Match, inout pkg.Inoutput) pkg.Inoutput {
+	for i := len(matches) - 1; i >= 0; i-- {
+		match := matches[i]
+		destination := strings.TrimSpace(string(match.Captures[0]))
+
+		if destination == "stop" {
+			inout.Input = inout.Input.Omit(match.Index)
+			world.stopWalk("Walk stopped.")
+
+			continue
+		}
+
+		move, ok := world.Goto(destination)
+		if !ok {
+			inout.Input = inout.Input.Omit(match.Index)
+			continue
+		}
+
+		inout.Input = inout.Input.Replace(match.Index, move)
+	}
+
+	return inout
+}
+
+// onWalkFailure stops walking when a move fails.
+func (world *World) onWalkFailure(_ []pkg.Match, inout pkg.Inoutput) pkg.Inoutput {
+	world.stopWalk("Walk stopped, as the way is blocked.")
+
+	return inout
+}
+
+// stepWalk continues walking when arriving in the next room along the path.
+// The game describes the room we're in again on things like "look", which
+// changes nothing for the walk.
+func (world *World) stepWalk(previous *navigation.Room) {
+	if len(world.walk) == 0 || world.Room == previous {
+		return
+	}
+
+	if world.Room != world.walk[0].Room {
+		world.stopWalk("Walk stopped, as you strayed from the path.")
+		return
+	}
+
+	world.walk = world.walk[1:]
+
+	if len(world.walk) == 0 {
+		world.ui.Print([]byte("You have arrived."))
+		return
+	}
+
+	world.client.Send([]byte(world.walk[0].Direction))
+}
+
+func (world *World) stopWalk(message string) {
+	if len(world.walk) == 0 {
+		return
+	}
+
+	world.walk = nil
+	world.ui.Print([]byte(message))
+}
@@ -0,0 +1,136 @@
+package achaea_test
+
+import (
+	"testing"
+
+	"github.com/tobiassjosten/nogfx/pkg"
+	"github.com/tobiassjosten/nogfx/pkg/mock"
+	"github.com/tobiassjosten/nogfx/pkg/navigation"
+	"github.com/tobiassjosten/nogfx/pkg/world/achaea"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGoto(t *testing.T) {
+	roomInfo := func(num int, exits map[string]int) []byte {
+		return wrapGMCP("Room.Info", map[string]any{
+			"num":   num,
+			"name":  map[int]string{1: "Square", 2: "Boulevard", 3: "Temple", 4: "Garden"}[num],
+			"exits": exits,
+		})
+	}
+
+	rooms := map[int]map[string]int{
+		1: {"n": 2, "e": 4},
+		2: {"n": 3, "s": 1},
+		3: {"s": 2},
+		4: {"w": 1},
+	}
+
+	setup := func(t *testing.T) (*achaea.World, *[]string, *[]string) {
+		var prints, sent []string
+
+		ui := &mock.UIMock{
+			PrintFunc: func(data []byte) {
+				prints = append(prints, string(data))
+			},
+			SetMapFunc:    func(_ *navigation.Map) {},
+			SetRoomFunc:   func(_ *navigation.Room) {},
+			SetTargetFunc: func(_ *pkg.Target) {},
+		}
+
+		client := &mock.ClientMock{
+			SendFunc: func(data []byte) {
+				sent = append(sent, string(data))
+			},
+		}
+
+		world, ok := achaea.NewWorld(client, ui, pkg.NewConfig()).(*achaea.World)
+		require.True(t, ok)
+
+		for _, id := range []int{2, 3, 4, 1} {
+			world.OnCommand(roomInfo(id, rooms[id]))
+		}
+
+		return world, &prints, &sent
+	}
+
+	t.Run("walks step by step", func(t *testing.T) {
+		world, prints, sent := setup(t)
+
+		inout := world.OnInoutput(pkg.NewInoutput([][]byte{[]byte("goto temple")}, nil))
+		assert.Equal(t, [][]byte{[]byte("n")}, inout.Input.Bytes())
+
+		world.OnCommand(roomInfo(2, rooms[2]))
+		assert.Equal(t, []string{"n"}, *sent)
+		assert.Empty(t, *prints)
+
+		world.OnCommand(roomInfo(3, rooms[3]))
+		assert.Equal(t, []string{"n"}, *sent)
+		assert.Equal(t, []string{"You have arrived."}, *prints)
+	})
+
+	t.Run("stops when blocked", func(t *testing.T) {
+		world, prints, sent := setup(t)
+
+		world.OnInoutput(pkg.NewInoutput([][]byte{[]byte("goto 3")}, nil))
+		world.OnInoutput(pkg.NewInoutput(nil, [][]byte{
+			[]byte("There is a door in the way."),
+			[]byte("3905h, 3846m"),
+		}))
+
+		world.OnCommand(roomInfo(2, rooms[2]))
+		assert.Empty(t, *sent)
+		assert.Equal(t, []string{"Walk stopped, as the way is blocked."}, *prints)
+	})
+
+	t.Run("waits in the same room", func(t *testing.T) {
+		world, prints, sent := setup(t)
+
+		world.OnInoutput(pkg.NewInoutput([][]byte{[]byte("goto 3")}, nil))
+		world.OnCommand(roomInfo(1, rooms[1]))
+
+		assert.Empty(t, *sent)
+		assert.Empty(t, *prints)
+
+		world.OnCommand(roomInfo(2, rooms[2]))
+		assert.Equal(t, []string{"n"}, *sent)
+	})
+
+	t.Run("stops when straying", func(t *testing.T) {
+		world, prints, sent := setup(t)
+
+		world.OnInoutput(pkg.NewInoutput([][]byte{[]byte("goto 3")}, nil))
+		world.OnCommand(roomInfo(4, rooms[4]))
+
+		assert.Empty(t, *sent)
+		assert.Equal(t, []string{"Walk stopped, as you strayed from the path."}, *prints)
+	})
+
+	t.Run("stops on command", func(t *testing.T) {
+		world, prints, _ := setup(t)
+
+		world.OnInoutput(pkg.NewInoutput([][]byte{[]byte("goto 3")}, nil))
+		inout := world.OnInoutput(pkg.NewInoutput([][]byte{[]byte("goto stop")}, nil))
+
+		assert.Empty(t, inout.Input.Bytes())
+		assert.Equal(t, []string{"Walk stopped."}, *prints)
+	})
+
+	t.Run("unknown destination", func(t *testing.T) {
+		world, prints, _ := setup(t)
+
+		inout := world.OnInoutput(pkg.NewInoutput([][]byte{[]byte("goto Nowhere")}, nil))
+
+		assert.Empty(t, inout.Input.Bytes())
+		assert.Equal(t, []string{"Can't find 'Nowhere' on the map."}, *prints)
+	})
+
+	t.Run("already there", func(t *testing.T) {
+		world, prints, _ := setup(t)
+
+		world.OnInoutput(pkg.NewInoutput([][]byte{[]byte("goto square")}, nil))
+		assert.Equal(t, []string{"You're already there."}, *prints)
+	})
+}
@@ -0,0 +1,318 @@
+package achaea
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/tobiassjosten/nogfx/pkg"
+	"github.com/tobiassjosten/nogfx/pkg/gmcp"
+
+	"golang.org/x/exp/slices"
+)
+
+// Inventory keeps track of what the character carries, wears and wields.
+type Inventory struct {
+	// Known is whether the inventory has been listed by the game yet.
+	Known bool
+
+	Items []gmcp.CharItem
+
+	// Contents lists what's in containers, by their IDs, as far as the
+	// game has told us.
+	Contents map[int][]gmcp.CharItem
+}
+
+// NewInventory creates a new, unknown, Inventory.
+func NewInventory() *Inventory {
+	return &Inventory{Contents: map[int][]gmcp.CharItem{}}
+}
+
+// InventoryGroup is a number of items with the same name.
+type InventoryGroup struct {
+	Item  gmcp.CharItem
+	Count int
+}
+
+// String describes the group, like "3 x an apple".
+func (group InventoryGroup) String() string {
+	if group.Count > 1 {
+		return fmt.Sprintf("%d x %s", group.Count, group.Item.Name)
+	}
+
+	return group.Item.Name
+}
+
+// Group collects items with the same name, in order of first appearance.
+func Group(items []gmcp.CharItem) []InventoryGroup {
+	groups := []InventoryGroup{}
+	indexes := map[string]int{}
+
+	for _, item := range items {
+		if i, ok := indexes[item.Name]; ok {
+			groups[i].Count++
+			continue
+		}
+
+		indexes[item.Name] = len(groups)
+		groups = append(groups, InventoryGroup{item, 1})
+	}
+
+	return groups
+}
+
+// Find lists carried items whose names contain the given text, regardless of
+// case, including those in containers.
+func (inv *Inventory) Find(name string) []gmcp.CharItem {
+	name = strings.ToLower(name)
+
+	found := []gmcp.CharItem{}
+
+	var find func(items []gmcp.CharItem)
+	find = func(items []gmcp.CharItem) {
+		for _, item := range items {
+			if strings.Contains(strings.ToLower(item.Name), name) {
+				found = append(found, item)
+			}
+
+			if item.Attributes.Container {
+				find(inv.Contents[item.ID])
+			}
+		}
+	}
+
+	find(inv.Items)
+
+	return found
+}
+
+// Has determines whether an item with the given name is carried.
+func (inv *Inventory) Has(name string) bool {
+	return len(inv.Find(name)) > 0
+}
+
+// Count counts carried items with the given name.
+func (inv *Inventory) Count(name string) int {
+	return len(inv.Find(name))
+}
+
+// Wielded lists items wielded in either hand.
+func (inv *Inventory) Wielded() []gmcp.CharItem {
+	return inv.filter(func(item gmcp.CharItem) bool {
+		return item.Attributes.WieldedLeft || item.Attributes.WieldedRight
+	})
+}
+
+// Worn lists items being worn.
+func (inv *Inventory) Worn() []gmcp.CharItem {
+	return inv.filter(func(item gmcp.CharItem) bool {
+		return item.Attributes.Worn
+	})
+}
+
+// Carried lists items neither wielded nor worn.
+func (inv *Inventory) Carried() []gmcp.CharItem {
+	return inv.filter(func(item gmcp.CharItem) bool {
+		as := item.Attributes
+		return !as.WieldedLeft && !as.WieldedRight && !as.Worn
+	})
+}
+
+func (inv *Inventory) filter(keep func(gmcp.CharItem) bool) []gmcp.CharItem {
+	items := []gmcp.CharItem{}
+
+	for _, item := range inv.Items {
+		if keep(item) {
+			items = append(items, item)
+		}
+	}
+
+	return items
+}
+
+// Lines describes the inventory for printing, with wielded and worn items
+// first and then what's carried, with the contents of containers indented
+// beneath them.
+func (inv *Inventory) Lines() []string {
+	lines := []string{}
+
+	sections := []struct {
+		name  string
+		items []gmcp.CharItem
+	}{
+		{"Wielded", inv.Wielded()},
+		{"Worn", inv.Worn()},
+		{"Carried", inv.Carried()},
+	}
+
+	for _, section := range sections {
+		if len(section.items) == 0 {
+			continue
+		}
+
+		lines = append(lines, section.name+":")
+		lines = append(lines, inv.lines(section.items, "  ")...)
+	}
+
+	if len(lines) == 0 {
+		lines = append(lines, "You are empty-handed.")
+	}
+
+	return lines
+}
+
+func (inv *Inventory) lines(items []gmcp.CharItem, indent string) []string {
+	lines := []string{}
+
+	for _, group := range Group(items) {
+		line := indent + group.String()
+
+		as := group.Item.Attributes
+		switch {
+		case as.WieldedLeft && as.WieldedRight:
+			line += " (both hands)"
+
+		case as.WieldedLeft:
+			line += " (left hand)"
+
+		case as.WieldedRight:
+			line += " (right hand)"
+		}
+
+		lines = append(lines, line)
+
+		if as.Container && group.Count == 1 {
+			lines = append(lines, inv.lines(inv.Contents[group.Item.ID], indent+"  ")...)
+		}
+	}
+
+	return lines
+}
+
+// container determines which container a GMCP location refers to, with 0 being
+// the inventory itself. It reports false for locations outside of it.
+func (inv *Inventory) container(location string) (int, bool) {
+	if location == "inv" {
+		return 0, true
+	}
+
+	if id, err := strconv.Atoi(strings.TrimPrefix(location, "rep")); err == nil {
+		return id, strings.HasPrefix(location, "rep")
+	}
+
+	return 0, false
+}
+
+func (inv *Inventory) items(container int) []gmcp.CharItem {
+	if container == 0 {
+		return inv.Items
+	}
+
+	return inv.Contents[container]
+}
+
+func (inv *Inventory) setItems(container int, items []gmcp.CharItem) {
+	if container == 0 {
+		inv.Items = items
+		inv.Known = true
+
+		return
+	}
+
+	inv.Contents[container] = items
+}
+
+// FromCharItemsList replaces the items in the inventory or a container, and
+// returns the containers whose contents aren't yet known.
+func (inv *Inventory) FromCharItemsList(msg *gmcp.CharItemsList) []int {
+	container, ok := inv.container(msg.Location)
+	if !ok {
+		return nil
+	}
+
+	inv.setItems(container, append([]gmcp.CharItem{}, msg.Items...))
+
+	return inv.unknown(msg.Items)
+}
+
+// FromCharItemsAdd adds an item to the inventory or a container, and returns
+// it if it's a container whose contents aren't yet known.
+func (inv *Inventory) FromCharItemsAdd(msg *gmcp.CharItemsAdd) []int {
+	container, ok := inv.container(msg.Location)
+	if !ok {
+		return nil
+	}
+
+	items := inv.remove(inv.items(container), msg.Item.ID)
+	inv.setItems(container, append(items, msg.Item))
+
+	return inv.unknown([]gmcp.CharItem{msg.Item})
+}
+
+// FromCharItemsRemove removes an item from the inventory or a container.
+func (inv *Inventory) FromCharItemsRemove(msg *gmcp.CharItemsRemove) {
+	container, ok := inv.container(msg.Location)
+	if !ok {
+		return
+	}
+
+	inv.setItems(container, inv.remove(inv.items(container), msg.Item.ID))
+	delete(inv.Contents, msg.Item.ID)
+}
+
+// FromCharItemsUpdate changes an item in the inventory or a container, like
+// when it's wielded or worn.
+func (inv *Inventory) FromCharItemsUpdate(msg *gmcp.CharItemsUpdate) {
+	container, ok := inv.container(msg.Location)
+	if !ok {
+		return
+	}
+
+	for i, item := range inv.items(container) {
+		if item.ID == msg.Item.ID {
+			inv.items(container)[i] = msg.Item
+		}
+	}
+}
+
+func (inv *Inventory) unknown(items []gmcp.CharItem) []int {
+	unknown := []int{}
+
+	for _, item := range items {
+		if _, ok := inv.Contents[item.ID]; item.Attributes.Container && !ok {
+			unknown = append(unknown, item.ID)
+		}
+	}
+
+	return unknown
+}
+
+func (inv *Inventory) remove(items []gmcp.CharItem, id int) []gmcp.CharItem {
+	i := slices.IndexFunc(items, func(item gmcp.CharItem) bool {
+		return item.ID == id
+	})
+
+	if i >= 0 {
+		return slices.Delete(items, i, i+1)
+	}
+
+	return items
+}
+
+// onInv lists the inventory as we know it, instead of asking the game. Until
+// the game has listed it for us, the command is sent on.
+func (world *World) onInv(matches []pkg.Match, inout pkg.Inoutput) pkg.Inoutput {
+	if !world.Inventory.Known {
+		return inout
+	}
+
+	for i := len(matches) - 1; i >= 0; i-- {
+		inout.Input = inout.Input.Omit(matches[i].Index)
+	}
+
+	for _, line := range world.Inventory.Lines() {
+		world.ui.Print([]byte(line))
+	}
+
+	return inout
+}
@@ -0,0 +1,118 @@
+package achaea_test
+
+import (
+	"testing"
+
+	"github.com/tobiassjosten/nogfx/pkg"
+	"github.com/tobiassjosten/nogfx/pkg/mock"
+	"github.com/tobiassjosten/nogfx/pkg/world/achaea"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInventory(t *testing.T) {
+	var prints, writes []string
+
+	ui := &mock.UIMock{
+		PrintFunc: func(data []byte) {
+			prints = append(prints, string(data))
+		},
+		SetOccupantsFunc: func(_ pkg.Occupants) {},
+		SetTargetFunc:    func(_ *pkg.Target) {},
+	}
+
+	client := &mock.ClientMock{
+		WriteFunc: func(data []byte) (int, error) {
+			writes = append(writes, string(data[3:len(data)-2]))
+			return len(data), nil
+		},
+	}
+
+	world, ok := achaea.NewWorld(client, ui, pkg.NewConfig()).(*achaea.World)
+	require.True(t, ok)
+
+	input := func(command string) [][]byte {
+		prints = nil
+
+		inout := world.OnInoutput(pkg.NewInoutput([][]byte{[]byte(command)}, nil))
+
+		return inout.Input.Bytes()
+	}
+
+	// The game lists the inventory until we know it.
+	assert.Equal(t, [][]byte{[]byte("inv")}, input("inv"))
+
+	item := func(id, name, attrib string) map[string]string {
+		return map[string]string{"id": id, "name": name, "attrib": attrib}
+	}
+
+	messages := []struct {
+		id   string
+		data any
+	}{
+		{"Char.Items.List", map[string]any{
+			"location": "inv",
+			"items": []map[string]string{
+				item("1", "a scimitar", "lL"),
+				item("2", "a cloak", "w"),
+				item("3", "a pack", "c"),
+				item("4", "an apple", "e"),
+				item("5", "an apple", "e"),
+			},
+		}},
+		{"Char.Items.List", map[string]any{
+			"location": "rep3",
+			"items":    []map[string]string{item("6", "some kelp", "eg")},
+		}},
+		{"Char.Items.Add", map[string]any{
+			"location": "rep3",
+			"item":     item("7", "some kelp", "eg"),
+		}},
+		{"Char.Items.Add", map[string]any{
+			"location": "inv",
+			"item":     item("8", "a ring", ""),
+		}},
+		{"Char.Items.Update", map[string]any{
+			"location": "inv",
+			"item":     item("8", "a ring", "w"),
+		}},
+		{"Char.Items.Remove", map[string]any{
+			"location": "inv",
+			"item":     item("5", "an apple", "e"),
+		}},
+		{"Char.Items.Add", map[string]any{
+			"location": "room",
+			"item":     item("9", "a rock", "t"),
+		}},
+	}
+
+	for _, msg := range messages {
+		world.OnCommand(wrapGMCP(msg.id, msg.data))
+	}
+
+	assert.Equal(t, []string{"Char.Items.Contents 3"}, writes)
+
+	assert.Empty(t, input("inv"))
+	assert.Equal(t, []string{
+		"Wielded:",
+		"  a scimitar (both hands)",
+		"Worn:",
+		"  a cloak",
+		"  a ring",
+		"Carried:",
+		"  a pack",
+		"    2 x some kelp",
+		"  an apple",
+	}, prints)
+
+	assert.True(t, world.Inventory.Has("kelp"))
+	assert.Equal(t, 2, world.Inventory.Count("KELP"))
+	assert.False(t, world.Inventory.Has("rock"))
+	assert.Len(t, world.Inventory.Wielded(), 1)
+}
+
+func TestInventoryEmpty(t *testing.T) {
+	inv := achaea.NewInventory()
+	assert.Equal(t, []string{"You are empty-handed."}, inv.Lines())
+}
@@ -0,0 +1,138 @@
+package achaea
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/tobiassjosten/nogfx/pkg"
+	"github.com/tobiassjosten/nogfx/pkg/gmcp"
+	"github.com/tobiassjosten/nogfx/pkg/navigation"
+)
+
+// FromClientMap remembers where the official map can be downloaded and lets
+// the player know about it, until it's been imported.
+func (world *World) FromClientMap(msg *gmcp.ClientMap) {
+	world.mapURL = msg.URL
+
+	if len(world.Map.Rooms) == 0 && world.mapURL != "" {
+		world.ui.Print([]byte(
+			"An official map is available. Type 'map download' to import it.",
+		))
+	}
+}
+
+// onMapImport imports a map file of the official format from the given path,
+// instead of sending the command to the game.
+func (world *World) onMapImport(matches []pkg.Match, inout pkg.Inoutput) pkg.Inoutput {
+	for i := len(matches) - 1; i >= 0; i-- {
+		inout.Input = inout.Input.Omit(matches[i].Index)
+	}
+
+	for _, match := range matches {
+		world.importMap(string(match.Captures[0]))
+	}
+
+	return inout
+}
+
+// onMapDownload downloads the official map, as announced by the game, and
+// imports it, instead of sending the command to the game. Downloading and
+// parsing happens in the background, with only the merge into our map done
+// as a task from the engine's loop.
+func (world *World) onMapDownload(matches []pkg.Match, inout pkg.Inoutput) pkg.Inoutput {
+	for i := len(matches) - 1; i >= 0; i-- {
+		inout.Input = inout.Input.Omit(matches[i].Index)
+	}
+
+	if world.mapURL == "" {
+		world.ui.Print([]byte("No official map has been announced by the game."))
+		return inout
+	}
+
+	path := world.config.Path("maps", "achaea.xml")
+	if path == "" {
+		path = filepath.Join(os.TempDir(), "nogfx-achaea.xml")
+	}
+
+	world.ui.Print([]byte(fmt.Sprintf("Downloading map from %s.", world.mapURL)))
+
+	go func(url string) {
+		if err := navigation.DownloadXML(url, path); err != nil {
+			world.tasks <- func() {
+				world.ui.Print([]byte(fmt.Sprintf("Map download failed: %s", err)))
+			}
+
+			return
+		}
+
+		file, err := navigation.ParseXMLFile(path)
+		world.tasks <- func() {
+			world.mergeMap(file, err)
+		}
+	}(world.mapURL)
+
+	return inout
+}
+
+func (world *World) importMap(path string) {
+	world.mergeMap(navigation.ParseXMLFile(path))
+}
+
+// mergeMap merges a parsed map file into our map and persists it.
+func (world *World) mergeMap(file *navigation.XMLFile, err error) {
+	if err != nil {
+		world.ui.Print([]byte(fmt.Sprintf("Map import failed: %s", err)))
+		return
+	}
+
+	before := len(world.Map.Rooms)
+
+	world.Map.MergeXML(file)
+
+	world.saveMap()
+	world.ui.SetMap(world.Map)
+
+	if world.Room != nil {
+		world.ui.SetRoom(world.Room)
+	}
+
+	world.ui.Print([]byte(fmt.Sprintf(
+		"Map imported, with %d rooms (%d new).",
+		len(world.Map.Rooms), len(world.Map.Rooms)-before,
+	)))
+}
+
+// How long to gather changes to the map before saving it, as walking about
+// can change room after room and the whole map is written each time.
+var mapSaveDelay = 10 * time.Second
+
+// scheduleMapSave saves the map a while from now, if it's changed, by way of a
+// task for the engine's loop.
+func (world *World) scheduleMapSave() {
+	if world.mapPath == "" || world.mapSaving || !world.Map.Modified() {
+		return
+	}
+
+	world.mapSaving = true
+
+	time.AfterFunc(mapSaveDelay, func() {
+		world.tasks <- func() {
+			world.mapSaving = false
+			world.saveMap()
+		}
+	})
+}
+
+// saveMap persists the map, if it's changed and there's somewhere to keep it.
+func (world *World) saveMap() {
+	if world.mapPath == "" || !world.Map.Modified() {
+		return
+	}
+
+	if err := world.Map.Save(world.mapPath); err != nil {
+		log.Printf("failed saving map: %s", err)
+	}
+}
@@ -0,0 +1,126 @@
+package achaea_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/tobiassjosten/nogfx/pkg"
+	"github.com/tobiassjosten/nogfx/pkg/mock"
+	"github.com/tobiassjosten/nogfx/pkg/navigation"
+	"github.com/tobiassjosten/nogfx/pkg/world/achaea"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const mapXML = `<?xml version="1.0" encoding="utf-8"?>
+<map>
+  <areas><area id="12" name="Hashan" /></areas>
+  <rooms>
+    <room id="1" area="12" title="Central square" environment="1">
+      <coord x="0" y="0" z="0" />
+      <exit direction="north" target="2" />
+    </room>
+    <room id="2" area="12" title="Boulevard" environment="1">
+      <coord x="0" y="1" z="0" />
+      <exit direction="south" target="1" />
+    </room>
+  </rooms>
+  <environments><environment id="1" name="Urban" /></environments>
+</map>`
+
+func TestMapImport(t *testing.T) {
+	var prints []string
+
+	ui := &mock.UIMock{
+		PrintFunc: func(data []byte) {
+			prints = append(prints, string(data))
+		},
+		SetMapFunc:  func(_ *navigation.Map) {},
+		SetRoomFunc: func(_ *navigation.Room) {},
+	}
+
+	config := pkg.NewConfig()
+	config.Dir = t.TempDir()
+
+	path := filepath.Join(t.TempDir(), "map.xml")
+	require.Nil(t, os.WriteFile(path, []byte(mapXML), 0600))
+
+	world, ok := achaea.NewWorld(&mock.ClientMock{}, ui, config).(*achaea.World)
+	require.True(t, ok)
+
+	inout := world.OnInoutput(pkg.NewInoutput([][]byte{[]byte("map import " + path)}, nil))
+	assert.Empty(t, inout.Input.Bytes())
+
+	assert.Equal(t, "Central square", world.Map.Rooms[1].Name)
+	assert.True(t, world.Map.Rooms[1].HasExit("n s"))
+	assert.Equal(t, []string{"Map imported, with 2 rooms (2 new)."}, prints)
+
+	// The imported map is persisted for later sessions.
+	nmap, err := navigation.LoadMap(config.Path("maps", "achaea.json"))
+	require.Nil(t, err)
+	assert.Equal(t, "Urban", nmap.Rooms[2].Environment)
+
+	prints = nil
+	world.OnInoutput(pkg.NewInoutput([][]byte{[]byte("map import /nonexistent.xml")}, nil))
+	require.Len(t, prints, 1)
+	assert.Contains(t, prints[0], "Map import failed: failed opening map xml")
+}
+
+func TestMapDownload(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(mapXML))
+	}))
+	defer server.Close()
+
+	var prints []string
+
+	ui := &mock.UIMock{
+		PrintFunc: func(data []byte) {
+			prints = append(prints, string(data))
+		},
+		SetMapFunc: func(_ *navigation.Map) {},
+	}
+
+	config := pkg.NewConfig()
+	config.Dir = t.TempDir()
+
+	world, ok := achaea.NewWorld(&mock.ClientMock{}, ui, config).(*achaea.World)
+	require.True(t, ok)
+
+	world.OnInoutput(pkg.NewInoutput([][]byte{[]byte("map download")}, nil))
+	assert.Equal(t, []string{"No official map has been announced by the game."}, prints)
+
+	prints = nil
+	world.OnCommand(wrapGMCP("Client.Map", map[string]string{"url": server.URL}))
+	assert.Equal(t, []string{
+		"An official map is available. Type 'map download' to import it.",
+	}, prints)
+
+	prints = nil
+	inout := world.OnInoutput(pkg.NewInoutput([][]byte{[]byte("map download")}, nil))
+	assert.Empty(t, inout.Input.Bytes())
+
+	// The map is downloaded in the background and merged by a task, run
+	// from the engine's loop.
+	assert.Equal(t, []string{"Downloading map from " + server.URL + "."}, prints)
+	assert.Empty(t, world.Map.Rooms)
+
+	task := <-world.Tasks()
+	task()
+
+	assert.Equal(t, []string{
+		"Downloading map from " + server.URL + ".",
+		"Map imported, with 2 rooms (2 new).",
+	}, prints)
+	assert.FileExists(t, config.Path("maps", "achaea.xml"))
+	assert.Equal(t, "Boulevard", world.Map.Rooms[2].Name)
+
+	// Once there's a map, we don't nag about the official one.
+	prints = nil
+	world.OnCommand(wrapGMCP("Client.Map", map[string]string{"url": server.URL}))
+	assert.Empty(t, prints)
+}
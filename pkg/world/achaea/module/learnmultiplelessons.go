@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"math"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/tobiassjosten/nogfx/pkg"
@@ -20,11 +21,16 @@ type LearnMultipleLessons struct {
 	target    []byte
 	start     time.Time
 	timer     *time.Timer
+
+	// ranks looks up the current rank in a skill group, to show progress
+	// alongside the lessons learned.
+	ranks func(group string) (string, bool)
 }
 
-// NewLearnMultipleLessons creates a new LearnMultipleLessons module.
-func NewLearnMultipleLessons() pkg.Module {
-	return &LearnMultipleLessons{}
+// NewLearnMultipleLessons creates a new LearnMultipleLessons module, showing
+// the rank in the skill as given by the optional ranks lookup.
+func NewLearnMultipleLessons(ranks func(group string) (string, bool)) pkg.Module {
+	return &LearnMultipleLessons{ranks: ranks}
 }
 
 // Triggers returns a list of triggers.
@@ -124,8 +130,8 @@ func (mod *LearnMultipleLessons) onFinish(matches []pkg.Match, inout pkg.Inoutpu
 	for _, match := range matches {
 		if mod.remaining <= 0 {
 			inout.Output = inout.Output.AddAfter(match.Index, []byte(fmt.Sprintf(
-				"%d of %d lessons learned.",
-				mod.total-mod.remaining, mod.total,
+				"%d of %d lessons learned%s.",
+				mod.total-mod.remaining, mod.total, mod.rank(),
 			)))
 
 			mod.reset()
@@ -147,8 +153,8 @@ func (mod *LearnMultipleLessons) onFinish(matches []pkg.Match, inout pkg.Inoutpu
 		timeleft += fmt.Sprintf("%.0f seconds", estimate.Seconds())
 
 		inout.Output = inout.Output.Replace(match.Index, []byte(fmt.Sprintf(
-			"%d of %d lessons learned, %s remaining.",
-			mod.total-mod.remaining, mod.total, timeleft,
+			"%d of %d lessons learned%s, %s remaining.",
+			mod.total-mod.remaining, mod.total, mod.rank(), timeleft,
 		)))
 
 		mod.start = time.Now()
@@ -159,6 +165,25 @@ func (mod *LearnMultipleLessons) onFinish(matches []pkg.Match, inout pkg.Inoutpu
 	return inout
 }
 
+// rank describes the current rank in the skill being learned, if known.
+func (mod *LearnMultipleLessons) rank() string {
+	if mod.ranks == nil {
+		return ""
+	}
+
+	group := strings.Fields(string(mod.target))
+	if len(group) == 0 {
+		return ""
+	}
+
+	rank, ok := mod.ranks(group[0])
+	if !ok {
+		return ""
+	}
+
+	return ", now " + rank
+}
+
 func (mod *LearnMultipleLessons) reset() {
 	if mod.timer != nil {
 		mod.timer.Stop()
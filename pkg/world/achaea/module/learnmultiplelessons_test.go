@@ -102,7 +102,70 @@ func TestLearnMultipleLessons(t *testing.T) {
 
 	for name, tc := range tcs {
 		t.Run(name, func(t *testing.T) {
-			mod := amodule.NewLearnMultipleLessons()
+			mod := amodule.NewLearnMultipleLessons(nil)
+			tc.Eval(t, mod)
+		})
+	}
+}
+
+func TestLearnMultipleLessonsRank(t *testing.T) {
+	ranks := func(group string) (string, bool) {
+		if group != "x" {
+			return "", false
+		}
+
+		return "Adept (45%)", true
+	}
+
+	tcs := map[string]tst.IOTestCase{
+		"known rank": {
+			Events: []tst.IOEvent{
+				tst.IOEIn("learn 20 x from y"),
+
+				tst.IOEOut("Y begins the lesson in X."),
+				tst.IOEOut("Y finishes the lesson in X."),
+
+				tst.IOEOut("Y begins the lesson in X."),
+				tst.IOEOut("Y finishes the lesson in X."),
+			},
+			Inoutputs: []pkg.Inoutput{
+				tst.IOIn("learn 15 x from y"),
+
+				tst.IOOut("Y begins the lesson in X."),
+				tst.IO(
+					"learn 5 x from y",
+					"15 of 20 lessons learned, now Adept (45%), 0 seconds remaining.",
+				),
+
+				tst.IOOut("Y begins the lesson in X.").OmitOutput(0),
+				tst.IOOut(
+					"Y finishes the lesson in X.",
+				).AddAfterOutput(0, []byte("20 of 20 lessons learned, now Adept (45%).")),
+			},
+		},
+
+		"unknown rank": {
+			Events: []tst.IOEvent{
+				tst.IOEIn("learn 20 z from y"),
+
+				tst.IOEOut("Y begins the lesson in Z."),
+				tst.IOEOut("Y finishes the lesson in Z."),
+			},
+			Inoutputs: []pkg.Inoutput{
+				tst.IOIn("learn 15 z from y"),
+
+				tst.IOOut("Y begins the lesson in Z."),
+				tst.IO(
+					"learn 5 z from y",
+					"15 of 20 lessons learned, 0 seconds remaining.",
+				),
+			},
+		},
+	}
+
+	for name, tc := range tcs {
+		t.Run(name, func(t *testing.T) {
+			mod := amodule.NewLearnMultipleLessons(ranks)
 			tc.Eval(t, mod)
 		})
 	}
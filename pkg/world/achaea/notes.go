@@ -0,0 +1,140 @@
+package achaea
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/tobiassjosten/nogfx/pkg"
+)
+
+// onRoomNote attaches a note to the current room, instead of sending the
+// command to the game.
+func (world *World) onRoomNote(matches []pkg.Match, inout pkg.Inoutput) pkg.Inoutput {
+	return world.onRoomCommand(matches, inout, func(note string) {
+		world.Map.AddNote(world.Room, note)
+		world.ui.Print([]byte("Note added."))
+	})
+}
+
+// onRoomUnnote removes all notes from the current room, instead of sending
+// the command to the game.
+func (world *World) onRoomUnnote(matches []pkg.Match, inout pkg.Inoutput) pkg.Inoutput {
+	return world.onRoomCommand(matches, inout, func(_ string) {
+		world.Map.ClearNotes(world.Room)
+		world.ui.Print([]byte("Notes removed."))
+	})
+}
+
+// onRoomTag tags the current room, instead of sending the command to the
+// game.
+func (world *World) onRoomTag(matches []pkg.Match, inout pkg.Inoutput) pkg.Inoutput {
+	return world.onRoomCommand(matches, inout, func(tag string) {
+		world.Map.Tag(world.Room, tag)
+		world.ui.Print([]byte(fmt.Sprintf("Room tagged '%s'.", strings.ToLower(tag))))
+	})
+}
+
+// onRoomUntag removes a tag from the current room, instead of sending the
+// command to the game.
+func (world *World) onRoomUntag(matches []pkg.Match, inout pkg.Inoutput) pkg.Inoutput {
+	return world.onRoomCommand(matches, inout, func(tag string) {
+		if !world.Map.Untag(world.Room, tag) {
+			world.ui.Print([]byte(fmt.Sprintf("Room isn't tagged '%s'.", tag)))
+			return
+		}
+
+		world.ui.Print([]byte(fmt.Sprintf("Room no longer tagged '%s'.", tag)))
+	})
+}
+
+// onRoomInfo shows what's been noted about the current room, instead of
+// sending the command to the game.
+func (world *World) onRoomInfo(matches []pkg.Match, inout pkg.Inoutput) pkg.Inoutput {
+	return world.onRoomCommand(matches, inout, func(_ string) {
+		room := world.Room
+
+		world.ui.Print([]byte(fmt.Sprintf("%s (%d)", room.Name, room.ID)))
+
+		if len(room.Tags) > 0 {
+			world.ui.Print([]byte("Tags: " + strings.Join(room.Tags, ", ")))
+		}
+
+		for _, note := range room.Notes {
+			world.ui.Print([]byte("Note: " + note))
+		}
+
+		if len(room.Tags) == 0 && len(room.Notes) == 0 {
+			world.ui.Print([]byte("No notes or tags."))
+		}
+	})
+}
+
+// onRoomCommand omits a room command from the input and runs it with its
+// argument, if there's a current room to run it on. The map is saved and
+// redrawn afterwards, in case the command changed it.
+func (world *World) onRoomCommand(matches []pkg.Match, inout pkg.Inoutput, command func(string)) pkg.Inoutput {
+	for i := len(matches) - 1; i >= 0; i-- {
+		inout.Input = inout.Input.Omit(matches[i].Index)
+	}
+
+	if world.Room == nil {
+		world.ui.Print([]byte("Can't do that without knowing where you are."))
+		return inout
+	}
+
+	for _, match := range matches {
+		argument := ""
+		if len(match.Captures) > 0 {
+			argument = strings.TrimSpace(string(match.Captures[0]))
+		}
+
+		command(argument)
+	}
+
+	world.saveMap()
+	world.ui.SetRoom(world.Room)
+
+	return inout
+}
+
+// onTagged lists rooms with the given tag, nearest first, instead of sending
+// the command to the game.
+func (world *World) onTagged(matches []pkg.Match, inout pkg.Inoutput) pkg.Inoutput {
+	for i := len(matches) - 1; i >= 0; i-- {
+		inout.Input = inout.Input.Omit(matches[i].Index)
+	}
+
+	for _, match := range matches {
+		tag := strings.TrimSpace(string(match.Captures[0]))
+
+		rooms := world.Map.Tagged(tag, world.Room)
+		if len(rooms) == 0 {
+			world.ui.Print([]byte(fmt.Sprintf("No rooms tagged '%s'.", tag)))
+			continue
+		}
+
+		for _, nearby := range rooms {
+			var distance string
+
+			switch nearby.Distance {
+			case -1:
+				distance = "unreachable"
+
+			case 0:
+				distance = "here"
+
+			case 1:
+				distance = "1 move away"
+
+			default:
+				distance = fmt.Sprintf("%d moves away", nearby.Distance)
+			}
+
+			world.ui.Print([]byte(fmt.Sprintf(
+				"%s (%d), %s", nearby.Room.Name, nearby.Room.ID, distance,
+			)))
+		}
+	}
+
+	return inout
+}
@@ -0,0 +1,86 @@
+package achaea_test
+
+import (
+	"testing"
+
+	"github.com/tobiassjosten/nogfx/pkg"
+	"github.com/tobiassjosten/nogfx/pkg/mock"
+	"github.com/tobiassjosten/nogfx/pkg/navigation"
+	"github.com/tobiassjosten/nogfx/pkg/world/achaea"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRoomNotes(t *testing.T) {
+	var prints []string
+
+	ui := &mock.UIMock{
+		PrintFunc: func(data []byte) {
+			prints = append(prints, string(data))
+		},
+		SetMapFunc:    func(_ *navigation.Map) {},
+		SetRoomFunc:   func(_ *navigation.Room) {},
+		SetTargetFunc: func(_ *pkg.Target) {},
+	}
+
+	world, ok := achaea.NewWorld(&mock.ClientMock{}, ui, pkg.NewConfig()).(*achaea.World)
+	require.True(t, ok)
+
+	input := func(command string) [][]byte {
+		prints = nil
+
+		inout := world.OnInoutput(pkg.NewInoutput([][]byte{[]byte(command)}, nil))
+
+		return inout.Input.Bytes()
+	}
+
+	assert.Empty(t, input("room tag gate"))
+	assert.Equal(t, []string{"Can't do that without knowing where you are."}, prints)
+
+	for _, info := range []map[string]any{
+		{"num": 2, "name": "Boulevard", "exits": map[string]int{"s": 1}},
+		{"num": 3, "name": "Gate", "exits": map[string]int{}},
+		{"num": 1, "name": "Square", "exits": map[string]int{"n": 2}},
+	} {
+		world.OnCommand(wrapGMCP("Room.Info", info))
+	}
+
+	input("room note harvest here")
+	assert.Equal(t, []string{"Note added."}, prints)
+
+	input("room tag City Gate")
+	assert.Equal(t, []string{"Room tagged 'city gate'."}, prints)
+
+	input("room info")
+	assert.Equal(t, []string{
+		"Square (1)",
+		"Tags: city gate",
+		"Note: harvest here",
+	}, prints)
+
+	world.Map.Tag(world.Map.Rooms[2], "city gate")
+	world.Map.Tag(world.Map.Rooms[3], "city gate")
+
+	input("tagged city gate")
+	assert.Equal(t, []string{
+		"Square (1), here",
+		"Boulevard (2), 1 move away",
+		"Gate (3), unreachable",
+	}, prints)
+
+	input("tagged harvest")
+	assert.Equal(t, []string{"No rooms tagged 'harvest'."}, prints)
+
+	input("room untag city gate")
+	assert.Equal(t, []string{"Room no longer tagged 'city gate'."}, prints)
+
+	input("room untag city gate")
+	assert.Equal(t, []string{"Room isn't tagged 'city gate'."}, prints)
+
+	input("room unnote")
+	assert.Equal(t, []string{"Notes removed."}, prints)
+
+	input("room info")
+	assert.Equal(t, []string{"Square (1)", "No notes or tags."}, prints)
+}
@@ -0,0 +1,153 @@
+package achaea
+
+import (
+	"strings"
+
+	"github.com/tobiassjosten/nogfx/pkg"
+	"github.com/tobiassjosten/nogfx/pkg/gmcp"
+
+	"golang.org/x/exp/slices"
+)
+
+// Item attributes worth pointing out about things in the room.
+var occupantDetails = []struct {
+	name string
+	has  func(gmcp.CharItemAttributes) bool
+}{
+	{"dead", func(as gmcp.CharItemAttributes) bool { return as.Dead }},
+	{"dangerous", func(as gmcp.CharItemAttributes) bool { return as.Dangerous }},
+	{"container", func(as gmcp.CharItemAttributes) bool { return as.Container }},
+	{"riftable", func(as gmcp.CharItemAttributes) bool { return as.Riftable }},
+}
+
+// Occupants keeps track of who and what is in the current room.
+type Occupants struct {
+	Players []gmcp.RoomPlayer
+	Items   []gmcp.CharItem
+
+	// Channels lists the channels shared with other players, by their
+	// lowercase names.
+	Channels map[string][]string
+}
+
+// PkgOccupants converts our game-specific Occupants to the general pkg struct,
+// with denizens marked when they're among the target's candidates and players
+// when they share a channel with the character, who is left out.
+func (occs *Occupants) PkgOccupants(target *pkg.Target, character string) pkg.Occupants {
+	occupants := pkg.Occupants{}
+
+	for _, player := range occs.Players {
+		if strings.EqualFold(player.Name, character) {
+			continue
+		}
+
+		occupants.Players = append(occupants.Players, pkg.Occupant{
+			Name:   player.Name,
+			Marked: len(occs.Channels[strings.ToLower(player.Name)]) > 0,
+		})
+	}
+
+	for _, item := range occs.Items {
+		occupant := pkg.Occupant{Name: item.Name}
+
+		for _, detail := range occupantDetails {
+			if detail.has(item.Attributes) {
+				occupant.Details = append(occupant.Details, detail.name)
+			}
+		}
+
+		if item.Attributes.Monster && !item.Attributes.Dead {
+			occupant.Marked = target != nil && target.IsCandidate(item.Name)
+			occupants.Denizens = append(occupants.Denizens, occupant)
+
+			continue
+		}
+
+		occupants.Items = append(occupants.Items, occupant)
+	}
+
+	return occupants
+}
+
+// FromRoomPlayers replaces the players in the room.
+func (occs *Occupants) FromRoomPlayers(msg *gmcp.RoomPlayers) {
+	occs.Players = append([]gmcp.RoomPlayer{}, *msg...)
+}
+
+// FromRoomAddPlayer adds a player entering the room.
+func (occs *Occupants) FromRoomAddPlayer(msg *gmcp.RoomAddPlayer) {
+	occs.FromRoomRemovePlayer((*gmcp.RoomRemovePlayer)(msg))
+	occs.Players = append(occs.Players, gmcp.RoomPlayer(*msg))
+}
+
+// FromRoomRemovePlayer removes a player leaving the room.
+func (occs *Occupants) FromRoomRemovePlayer(msg *gmcp.RoomRemovePlayer) {
+	i := slices.IndexFunc(occs.Players, func(player gmcp.RoomPlayer) bool {
+		return player.Name == msg.Name
+	})
+
+	if i >= 0 {
+		occs.Players = slices.Delete(occs.Players, i, i+1)
+	}
+}
+
+// FromCommChannelPlayers updates which channels are shared with whom.
+func (occs *Occupants) FromCommChannelPlayers(msg *gmcp.CommChannelPlayers) {
+	occs.Channels = map[string][]string{}
+
+	for _, player := range *msg {
+		occs.Channels[strings.ToLower(player.Name)] = player.Channels
+	}
+}
+
+// FromCharItemsList replaces the items in the room.
+func (occs *Occupants) FromCharItemsList(msg *gmcp.CharItemsList) {
+	if msg.Location != "room" {
+		return
+	}
+
+	occs.Items = append([]gmcp.CharItem{}, msg.Items...)
+}
+
+// FromCharItemsAdd adds an item to the room.
+func (occs *Occupants) FromCharItemsAdd(msg *gmcp.CharItemsAdd) {
+	if msg.Location != "room" {
+		return
+	}
+
+	occs.Items = append(occs.removeItem(msg.Item.ID), msg.Item)
+}
+
+// FromCharItemsRemove removes an item from the room.
+func (occs *Occupants) FromCharItemsRemove(msg *gmcp.CharItemsRemove) {
+	if msg.Location != "room" {
+		return
+	}
+
+	occs.Items = occs.removeItem(msg.Item.ID)
+}
+
+// FromCharItemsUpdate changes an item in the room.
+func (occs *Occupants) FromCharItemsUpdate(msg *gmcp.CharItemsUpdate) {
+	if msg.Location != "room" {
+		return
+	}
+
+	for i, item := range occs.Items {
+		if item.ID == msg.Item.ID {
+			occs.Items[i] = msg.Item
+		}
+	}
+}
+
+func (occs *Occupants) removeItem(id int) []gmcp.CharItem {
+	i := slices.IndexFunc(occs.Items, func(item gmcp.CharItem) bool {
+		return item.ID == id
+	})
+
+	if i >= 0 {
+		return slices.Delete(occs.Items, i, i+1)
+	}
+
+	return occs.Items
+}
@@ -0,0 +1,96 @@
+package achaea_test
+
+import (
+	"testing"
+
+	"github.com/tobiassjosten/nogfx/pkg"
+	"github.com/tobiassjosten/nogfx/pkg/mock"
+	"github.com/tobiassjosten/nogfx/pkg/navigation"
+	"github.com/tobiassjosten/nogfx/pkg/world/achaea"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOccupants(t *testing.T) {
+	var occupants pkg.Occupants
+
+	ui := &mock.UIMock{
+		SetOccupantsFunc: func(occs pkg.Occupants) {
+			occupants = occs
+		},
+		SetMapFunc:    func(_ *navigation.Map) {},
+		SetRoomFunc:   func(_ *navigation.Room) {},
+		SetTargetFunc: func(_ *pkg.Target) {},
+	}
+
+	client := &mock.ClientMock{
+		SendFunc:  func(_ []byte) {},
+		WriteFunc: func(data []byte) (int, error) { return len(data), nil },
+	}
+
+	world, ok := achaea.NewWorld(client, ui, pkg.NewConfig()).(*achaea.World)
+	require.True(t, ok)
+
+	messages := []struct {
+		id   string
+		data any
+	}{
+		{"Char.Name", map[string]string{"name": "Durak", "fullname": "Durak"}},
+		{"Room.Info", map[string]any{"num": 1, "name": "Village", "coords": "137,0,0"}},
+		{"Comm.Channel.Players", []map[string]any{
+			{"name": "Jane", "channels": []string{"Newbie"}},
+			{"name": "Bob"},
+		}},
+		{"Room.Players", []map[string]string{
+			{"name": "Durak", "fullname": "Durak"},
+			{"name": "Bob", "fullname": "Bob"},
+		}},
+		{"Room.AddPlayer", map[string]string{"name": "Jane", "fullname": "Jane"}},
+		{"Char.Items.List", map[string]any{
+			"location": "room",
+			"items": []map[string]string{
+				{"id": "1", "name": "a ferocious manticore", "attrib": "m"},
+				{"id": "2", "name": "a rat", "attrib": "m"},
+				{"id": "3", "name": "a chest", "attrib": "c"},
+			},
+		}},
+		{"Char.Items.Add", map[string]any{
+			"location": "room",
+			"item":     map[string]string{"id": "4", "name": "a sword", "attrib": "t"},
+		}},
+		{"Char.Items.Update", map[string]any{
+			"location": "room",
+			"item":     map[string]string{"id": "2", "name": "the corpse of a rat", "attrib": "dt"},
+		}},
+		{"Char.Items.Remove", map[string]any{
+			"location": "room",
+			"item":     map[string]string{"id": "4", "name": "a sword"},
+		}},
+		{"Char.Items.Add", map[string]any{
+			"location": "inv",
+			"item":     map[string]string{"id": "5", "name": "a shield"},
+		}},
+	}
+
+	for _, msg := range messages {
+		world.OnCommand(wrapGMCP(msg.id, msg.data))
+	}
+
+	assert.Equal(t, pkg.Occupants{
+		Players: []pkg.Occupant{
+			{Name: "Bob"},
+			{Name: "Jane", Marked: true},
+		},
+		Denizens: []pkg.Occupant{
+			{Name: "a ferocious manticore", Marked: true},
+		},
+		Items: []pkg.Occupant{
+			{Name: "the corpse of a rat", Details: []string{"dead"}},
+			{Name: "a chest", Details: []string{"container"}},
+		},
+	}, occupants)
+
+	world.OnCommand(wrapGMCP("Room.RemovePlayer", map[string]string{"name": "Bob"}))
+	assert.Equal(t, []pkg.Occupant{{Name: "Jane", Marked: true}}, occupants.Players)
+}
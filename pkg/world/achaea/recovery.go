@@ -0,0 +1,69 @@
+package achaea
+
+import (
+	"time"
+)
+
+// Number of recovery times kept per action.
+const recoveryHistory = 10
+
+// Recovery measures how long it takes to recover a balance, like balance or
+// equilibrium, and keeps a history per action that spent it.
+type Recovery struct {
+	// Last is how long the most recent loss lasted.
+	Last time.Duration
+
+	// History lists recent recovery times, from oldest to newest, by the
+	// action that spent the balance.
+	History map[string][]time.Duration
+
+	lost   time.Time
+	action string
+}
+
+// Update registers whether the balance is had at the given time, with the
+// action being what most recently was done to spend it.
+func (r *Recovery) Update(has bool, action string, now time.Time) {
+	if !has {
+		if r.lost.IsZero() {
+			r.lost = now
+			r.action = action
+		}
+
+		return
+	}
+
+	if r.lost.IsZero() {
+		return
+	}
+
+	r.Last = now.Sub(r.lost)
+	r.lost = time.Time{}
+
+	if r.History == nil {
+		r.History = map[string][]time.Duration{}
+	}
+
+	history := append(r.History[r.action], r.Last)
+	if len(history) > recoveryHistory {
+		history = history[len(history)-recoveryHistory:]
+	}
+
+	r.History[r.action] = history
+}
+
+// Average calculates the mean recovery time of the given action, or zero if
+// there's no history of it.
+func (r *Recovery) Average(action string) time.Duration {
+	history := r.History[action]
+	if len(history) == 0 {
+		return 0
+	}
+
+	var total time.Duration
+	for _, duration := range history {
+		total += duration
+	}
+
+	return total / time.Duration(len(history))
+}
@@ -0,0 +1,52 @@
+package achaea_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/tobiassjosten/nogfx/pkg/world/achaea"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRecovery(t *testing.T) {
+	start := time.Date(2023, 5, 1, 12, 0, 0, 0, time.UTC)
+	at := func(ms int) time.Time {
+		return start.Add(time.Duration(ms) * time.Millisecond)
+	}
+
+	recovery := &achaea.Recovery{}
+
+	// Regaining what was never lost isn't a recovery.
+	recovery.Update(true, "kick", at(0))
+	assert.Equal(t, time.Duration(0), recovery.Last)
+	assert.Nil(t, recovery.History)
+
+	recovery.Update(false, "kick", at(0))
+	recovery.Update(false, "punch", at(1000))
+	recovery.Update(true, "punch", at(2500))
+	assert.Equal(t, 2500*time.Millisecond, recovery.Last)
+
+	recovery.Update(false, "kick", at(3000))
+	recovery.Update(true, "kick", at(6500))
+	assert.Equal(t, 3500*time.Millisecond, recovery.Last)
+
+	recovery.Update(false, "punch", at(7000))
+	recovery.Update(true, "punch", at(8000))
+
+	assert.Equal(t, map[string][]time.Duration{
+		"kick":  {2500 * time.Millisecond, 3500 * time.Millisecond},
+		"punch": {time.Second},
+	}, recovery.History)
+
+	assert.Equal(t, 3*time.Second, recovery.Average("kick"))
+	assert.Equal(t, time.Duration(0), recovery.Average("kiss"))
+
+	for i := 0; i < 20; i++ {
+		recovery.Update(false, "kick", at(10000+i*1000))
+		recovery.Update(true, "kick", at(10000+i*1000+500))
+	}
+
+	assert.Len(t, recovery.History["kick"], 10)
+	assert.Equal(t, 500*time.Millisecond, recovery.Average("kick"))
+}
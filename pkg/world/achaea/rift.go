@@ -0,0 +1,182 @@
+package achaea
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/tobiassjosten/nogfx/pkg"
+	igmcp "github.com/tobiassjosten/nogfx/pkg/gmcp/ironrealms"
+
+	"golang.org/x/exp/slices"
+)
+
+// riftCuratives lists herbs and the minerals they can be substituted for, in
+// the order they're shown in the rift pane.
+var riftCuratives = [][2]string{
+	{"kelp", "aurum"},
+	{"ginseng", "ferrum"},
+	{"goldenseal", "plumbum"},
+	{"bloodroot", "magnesium"},
+	{"lobelia", "argentum"},
+	{"bellwort", "cuprum"},
+	{"ash", "stannum"},
+	{"hawthorn", "calamine"},
+	{"bayberry", "arsenic"},
+	{"ginger", "antimony"},
+	{"moss", "potash"},
+	{"pear", "calcite"},
+	{"kola", "quartz"},
+	{"elm", "cinnabar"},
+	{"skullcap", "azurite"},
+	{"valerian", "realgar"},
+	{"cohosh", "gypsum"},
+	{"sileris", "quicksilver"},
+	{"echinacea", "dolomite"},
+	{"myrrh", "bisemutum"},
+}
+
+// riftSubstitute finds the herb for a mineral or the mineral for a herb.
+func riftSubstitute(name string) (string, bool) {
+	for _, pair := range riftCuratives {
+		switch name {
+		case pair[0]:
+			return pair[1], true
+
+		case pair[1]:
+			return pair[0], true
+		}
+	}
+
+	return "", false
+}
+
+// Rift keeps track of what's stored in the rift.
+type Rift struct {
+	// Known is whether the rift has been listed by the game yet.
+	Known bool
+
+	Items []igmcp.IRERiftItem
+}
+
+// Amount tells how many of an item there are in the rift.
+func (rift *Rift) Amount(name string) int {
+	if i := rift.index(name); i >= 0 {
+		return rift.Items[i].Amount
+	}
+
+	return 0
+}
+
+func (rift *Rift) index(name string) int {
+	return slices.IndexFunc(rift.Items, func(item igmcp.IRERiftItem) bool {
+		return item.Name == name
+	})
+}
+
+// PkgRift converts the curatives in our game-specific Rift to the general pkg
+// struct, marking those below the given thresholds.
+func (rift *Rift) PkgRift(thresholds map[string]int) []pkg.RiftItem {
+	items := []pkg.RiftItem{}
+
+	for _, pair := range riftCuratives {
+		for _, name := range pair {
+			amount := rift.Amount(name)
+
+			threshold, ok := thresholds[name]
+			if amount == 0 && !ok {
+				continue
+			}
+
+			items = append(items, pkg.RiftItem{
+				Name:   name,
+				Amount: amount,
+				Low:    ok && amount < threshold,
+			})
+		}
+	}
+
+	return items
+}
+
+// FromIRERiftList replaces what's in the rift.
+func (rift *Rift) FromIRERiftList(msg *igmcp.IRERiftList) {
+	rift.Known = true
+	rift.Items = append([]igmcp.IRERiftItem{}, *msg...)
+}
+
+// FromIRERiftChange updates the amount of an item in the rift, removing it
+// once there's none left.
+func (rift *Rift) FromIRERiftChange(msg *igmcp.IRERiftChange) {
+	i := rift.index(msg.Name)
+
+	switch {
+	case i >= 0 && msg.Amount <= 0:
+		rift.Items = slices.Delete(rift.Items, i, i+1)
+
+	case i >= 0:
+		rift.Items[i] = igmcp.IRERiftItem(*msg)
+
+	case msg.Amount > 0:
+		rift.Items = append(rift.Items, igmcp.IRERiftItem(*msg))
+	}
+}
+
+// warnRift points out when an item has dropped below its configured threshold.
+func (world *World) warnRift(name string, previous, current int) {
+	threshold, ok := world.config.Rift.Thresholds[name]
+	if !ok || previous < threshold || current >= threshold {
+		return
+	}
+
+	world.ui.Print([]byte(fmt.Sprintf(
+		"Running low on %s, with %d left in the rift.", name, current,
+	)))
+}
+
+// onOutr takes items out of the rift, substituting herbs and minerals for one
+// another when there's none of what was asked for. Until the game has listed
+// the rift for us, the command is sent as is.
+func (world *World) onOutr(matches []pkg.Match, inout pkg.Inoutput) pkg.Inoutput {
+	if !world.Rift.Known {
+		return inout
+	}
+
+	for i := len(matches) - 1; i >= 0; i-- {
+		match := matches[i]
+
+		count := ""
+		name := strings.ToLower(strings.TrimSpace(string(match.Captures[0])))
+
+		if parts := strings.SplitN(name, " ", 2); len(parts) == 2 {
+			if _, err := strconv.Atoi(parts[0]); err == nil {
+				count, name = parts[0]+" ", parts[1]
+			}
+		}
+
+		if world.Rift.Amount(name) > 0 {
+			continue
+		}
+
+		substitute, ok := riftSubstitute(name)
+		if ok && world.Rift.Amount(substitute) > 0 {
+			inout.Input = inout.Input.Replace(
+				match.Index, []byte(fmt.Sprintf("outr %s%s", count, substitute)),
+			)
+
+			continue
+		}
+
+		inout.Input = inout.Input.Omit(match.Index)
+
+		if ok {
+			world.ui.Print([]byte(fmt.Sprintf(
+				"There's no %s or %s in the rift.", name, substitute,
+			)))
+		} else {
+			world.ui.Print([]byte(fmt.Sprintf("There's no %s in the rift.", name)))
+		}
+	}
+
+	return inout
+}
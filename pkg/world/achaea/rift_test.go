@@ -0,0 +1,108 @@
+package achaea_test
+
+import (
+	"testing"
+
+	"github.com/tobiassjosten/nogfx/pkg"
+	"github.com/tobiassjosten/nogfx/pkg/mock"
+	"github.com/tobiassjosten/nogfx/pkg/world/achaea"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRift(t *testing.T) {
+	var prints []string
+
+	var rift []pkg.RiftItem
+
+	ui := &mock.UIMock{
+		PrintFunc: func(data []byte) {
+			prints = append(prints, string(data))
+		},
+		SetRiftFunc: func(items []pkg.RiftItem) {
+			rift = items
+		},
+	}
+
+	config := pkg.NewConfig()
+	config.Rift.Thresholds = map[string]int{"kelp": 10, "ginseng": 5}
+
+	world, ok := achaea.NewWorld(&mock.ClientMock{}, ui, config).(*achaea.World)
+	require.True(t, ok)
+
+	input := func(command string) [][]byte {
+		prints = nil
+
+		inout := world.OnInoutput(pkg.NewInoutput([][]byte{[]byte(command)}, nil))
+
+		return inout.Input.Bytes()
+	}
+
+	// Commands are sent as is until we know what's in the rift.
+	assert.Equal(t, [][]byte{[]byte("outr kelp")}, input("outr kelp"))
+
+	item := func(name, amount string) map[string]string {
+		return map[string]string{"name": name, "amount": amount, "desc": name}
+	}
+
+	world.OnCommand(wrapGMCP("IRE.Rift.List", []map[string]string{
+		item("kelp", "11"),
+		item("aurum", "3"),
+		item("bloodroot", "7"),
+		item("sulphur", "2"),
+	}))
+
+	assert.Equal(t, []pkg.RiftItem{
+		{Name: "kelp", Amount: 11},
+		{Name: "aurum", Amount: 3},
+		{Name: "ginseng", Amount: 0, Low: true},
+		{Name: "bloodroot", Amount: 7},
+	}, rift)
+
+	world.OnCommand(wrapGMCP("IRE.Rift.Change", item("kelp", "9")))
+	world.OnCommand(wrapGMCP("IRE.Rift.Change", item("kelp", "0")))
+	world.OnCommand(wrapGMCP("IRE.Rift.Change", item("ferrum", "4")))
+
+	assert.Equal(t, []string{"Running low on kelp, with 9 left in the rift."}, prints)
+	assert.Equal(t, 0, world.Rift.Amount("kelp"))
+	assert.Equal(t, 4, world.Rift.Amount("ferrum"))
+
+	tcs := map[string]struct {
+		command string
+		inputs  [][]byte
+		prints  []string
+	}{
+		"available": {
+			command: "outr bloodroot",
+			inputs:  [][]byte{[]byte("outr bloodroot")},
+		},
+
+		"substituted": {
+			command: "outr kelp",
+			inputs:  [][]byte{[]byte("outr aurum")},
+		},
+
+		"substituted with count": {
+			command: "outr 2 ginseng",
+			inputs:  [][]byte{[]byte("outr 2 ferrum")},
+		},
+
+		"missing": {
+			command: "outr lobelia",
+			prints:  []string{"There's no lobelia or argentum in the rift."},
+		},
+
+		"missing without substitute": {
+			command: "outr nightshade",
+			prints:  []string{"There's no nightshade in the rift."},
+		},
+	}
+
+	for name, tc := range tcs {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.inputs, input(tc.command))
+			assert.Equal(t, tc.prints, prints)
+		})
+	}
+}
@@ -0,0 +1,146 @@
+package achaea
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/tobiassjosten/nogfx/pkg"
+	"github.com/tobiassjosten/nogfx/pkg/gmcp"
+)
+
+// Skills keeps track of the character's skill groups and the skills within
+// them, as the game lists them.
+type Skills struct {
+	Groups []pkg.SkillGroup
+}
+
+func (skills *Skills) group(name string) (*pkg.SkillGroup, bool) {
+	for i, group := range skills.Groups {
+		if strings.EqualFold(group.Name, name) {
+			return &skills.Groups[i], true
+		}
+	}
+
+	return nil, false
+}
+
+// Rank describes the rank in the given skill group, like "Adept (45%)". It
+// reports false for groups the character doesn't have.
+func (skills *Skills) Rank(name string) (string, bool) {
+	group, ok := skills.group(name)
+	if !ok {
+		return "", false
+	}
+
+	return group.RankString(), true
+}
+
+// PkgSkills converts our game-specific Skills to the general pkg struct.
+func (skills *Skills) PkgSkills() []pkg.SkillGroup {
+	groups := make([]pkg.SkillGroup, len(skills.Groups))
+
+	for i, group := range skills.Groups {
+		groups[i] = group
+
+		if group.Skills != nil {
+			groups[i].Skills = append([]pkg.Skill{}, group.Skills...)
+		}
+	}
+
+	return groups
+}
+
+// FromCharSkillsGroups updates the skill groups, keeping the skills already
+// listed for them. It returns the groups whose ranks have changed, unless
+// this is the first we hear of them, and those whose skills are yet unknown.
+func (skills *Skills) FromCharSkillsGroups(msg *gmcp.CharSkillsGroups) (changed []pkg.SkillGroup, unlisted []string) {
+	groups := []pkg.SkillGroup{}
+
+	for _, mgroup := range *msg {
+		group := pkg.SkillGroup{Name: mgroup.Name, Rank: mgroup.Rank, Progress: -1}
+		if mgroup.Progress != nil {
+			group.Progress = *mgroup.Progress
+		}
+
+		if old, ok := skills.group(group.Name); ok {
+			group.Skills = old.Skills
+
+			if old.Rank != group.Rank {
+				changed = append(changed, group)
+			}
+		}
+
+		if group.Skills == nil {
+			unlisted = append(unlisted, group.Name)
+		}
+
+		groups = append(groups, group)
+	}
+
+	skills.Groups = groups
+
+	return changed, unlisted
+}
+
+// FromCharSkillsList updates the skills of a group, keeping any information
+// already looked up about them.
+func (skills *Skills) FromCharSkillsList(msg *gmcp.CharSkillsList) {
+	group, ok := skills.group(msg.Group)
+	if !ok {
+		return
+	}
+
+	list := []pkg.Skill{}
+
+	for i, name := range msg.List {
+		skill := pkg.Skill{Name: name}
+
+		if i < len(msg.Descriptions) {
+			skill.Description = msg.Descriptions[i]
+		}
+
+		for _, old := range group.Skills {
+			if strings.EqualFold(old.Name, name) {
+				skill.Info = old.Info
+			}
+		}
+
+		list = append(list, skill)
+	}
+
+	group.Skills = list
+}
+
+// FromCharSkillsInfo remembers the full explanation of a skill.
+func (skills *Skills) FromCharSkillsInfo(msg *gmcp.CharSkillsInfo) {
+	group, ok := skills.group(msg.Group)
+	if !ok {
+		return
+	}
+
+	for i, skill := range group.Skills {
+		if strings.EqualFold(skill.Name, msg.Skill) {
+			group.Skills[i].Info = msg.Info
+		}
+	}
+}
+
+// onSkillInfo asks the game about a skill, instead of sending the command to
+// the game.
+func (world *World) onSkillInfo(matches []pkg.Match, inout pkg.Inoutput) pkg.Inoutput {
+	for i := len(matches) - 1; i >= 0; i-- {
+		inout.Input = inout.Input.Omit(matches[i].Index)
+	}
+
+	for _, match := range matches {
+		err := world.SendGMCP(&gmcp.CharSkillsGet{
+			Group: string(match.Captures[0]),
+			Name:  string(match.Captures[1]),
+		})
+		if err != nil {
+			world.ui.Print([]byte(fmt.Sprintf("Failed looking up skill: %s", err)))
+		}
+	}
+
+	return inout
+}
@@ -0,0 +1,100 @@
+package achaea_test
+
+import (
+	"testing"
+
+	"github.com/tobiassjosten/nogfx/pkg"
+	"github.com/tobiassjosten/nogfx/pkg/mock"
+	"github.com/tobiassjosten/nogfx/pkg/world/achaea"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSkills(t *testing.T) {
+	var prints, writes []string
+
+	var skills []pkg.SkillGroup
+
+	ui := &mock.UIMock{
+		PrintFunc: func(data []byte) {
+			prints = append(prints, string(data))
+		},
+		SetSkillsFunc: func(skillGroups []pkg.SkillGroup) {
+			skills = skillGroups
+		},
+	}
+
+	client := &mock.ClientMock{
+		WriteFunc: func(data []byte) (int, error) {
+			writes = append(writes, string(data[3:len(data)-2]))
+			return len(data), nil
+		},
+	}
+
+	world, ok := achaea.NewWorld(client, ui, pkg.NewConfig()).(*achaea.World)
+	require.True(t, ok)
+
+	world.OnCommand(wrapGMCP("Char.Skills.Groups", []map[string]string{
+		{"name": "Survival", "rank": "Adept (45%)"},
+		{"name": "Tattoos", "rank": "Inept"},
+	}))
+
+	assert.Empty(t, prints)
+	assert.Equal(t, []string{
+		`Char.Skills.Get {"group":"Survival"}`,
+		`Char.Skills.Get {"group":"Tattoos"}`,
+	}, writes)
+	assert.Equal(t, []pkg.SkillGroup{
+		{Name: "Survival", Rank: "Adept", Progress: 45},
+		{Name: "Tattoos", Rank: "Inept", Progress: -1},
+	}, skills)
+
+	writes = nil
+
+	world.OnCommand(wrapGMCP("Char.Skills.List", map[string]any{
+		"group": "survival",
+		"list":  []string{"Swimming", "Fitness"},
+		"descs": []string{"Stay afloat.", "Cure asthma."},
+	}))
+	world.OnCommand(wrapGMCP("Char.Skills.Info", map[string]string{
+		"group": "survival",
+		"skill": "fitness",
+		"info":  "Fitness cures asthma.",
+	}))
+
+	assert.Equal(t, []string{"Fitness cures asthma."}, prints)
+	assert.Equal(t, []pkg.Skill{
+		{Name: "Swimming", Description: "Stay afloat."},
+		{Name: "Fitness", Description: "Cure asthma.", Info: "Fitness cures asthma."},
+	}, skills[0].Skills)
+
+	prints = nil
+
+	world.OnCommand(wrapGMCP("Char.Skills.Groups", []map[string]string{
+		{"name": "Survival", "rank": "Gifted (3%)"},
+		{"name": "Tattoos", "rank": "Inept"},
+	}))
+
+	assert.Equal(t, []string{"Your rank in Survival is now Gifted (3%)."}, prints)
+	assert.Equal(t, []string{`Char.Skills.Get {"group":"Tattoos"}`}, writes)
+	assert.Len(t, skills[0].Skills, 2)
+
+	rank, ok := world.Skills.Rank("SURVIVAL")
+	assert.True(t, ok)
+	assert.Equal(t, "Gifted (3%)", rank)
+
+	_, ok = world.Skills.Rank("Necromancy")
+	assert.False(t, ok)
+
+	writes = nil
+
+	inout := world.OnInoutput(pkg.NewInoutput(
+		[][]byte{[]byte("skill info survival swimming")}, nil,
+	))
+
+	assert.Empty(t, inout.Input.Bytes())
+	assert.Equal(t, []string{
+		`Char.Skills.Get {"group":"survival","name":"swimming"}`,
+	}, writes)
+}
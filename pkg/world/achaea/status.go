@@ -0,0 +1,34 @@
+package achaea
+
+import (
+	"fmt"
+)
+
+// statusChange is a change of some countable part of the character's status,
+// like gold or lessons.
+type statusChange struct {
+	name  string
+	delta int
+	value int
+}
+
+// notifyStatus points out changes to gold, lessons and unread news and
+// messages between the previous and current state of the character.
+func (world *World) notifyStatus(previous, current Character) {
+	changes := []statusChange{
+		{"Gold", current.Gold - previous.Gold, current.Gold},
+		{"Lessons", current.Lessons - previous.Lessons, current.Lessons},
+		{"Unread news", current.UnreadNews - previous.UnreadNews, current.UnreadNews},
+		{"Unread messages", current.UnreadMessages - previous.UnreadMessages, current.UnreadMessages},
+	}
+
+	for _, change := range changes {
+		if change.delta == 0 {
+			continue
+		}
+
+		world.ui.Print([]byte(fmt.Sprintf(
+			"%s: %d (%+d)", change.name, change.value, change.delta,
+		)))
+	}
+}
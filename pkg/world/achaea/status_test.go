@@ -0,0 +1,58 @@
+package achaea_test
+
+import (
+	"testing"
+
+	"github.com/tobiassjosten/nogfx/pkg"
+	"github.com/tobiassjosten/nogfx/pkg/mock"
+	"github.com/tobiassjosten/nogfx/pkg/world/achaea"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStatusNotifications(t *testing.T) {
+	var prints []string
+
+	var character pkg.Character
+
+	ui := &mock.UIMock{
+		PrintFunc: func(data []byte) {
+			prints = append(prints, string(data))
+		},
+		SetCharacterFunc: func(char pkg.Character) {
+			character = char
+		},
+		SetTargetFunc: func(_ *pkg.Target) {},
+	}
+
+	world, ok := achaea.NewWorld(&mock.ClientMock{}, ui, pkg.NewConfig()).(*achaea.World)
+	require.True(t, ok)
+
+	// The first status carries everything and isn't worth pointing out.
+	world.OnCommand(wrapGMCP("Char.Status", map[string]string{
+		"name":        "Durak",
+		"gold":        "100",
+		"lessons":     "30",
+		"unread_news": "2",
+		"unread_msgs": "0",
+	}))
+
+	assert.Empty(t, prints)
+	assert.Contains(t, character.Sheet, pkg.CharacterDetail{Name: "Gold", Value: "100"})
+
+	world.OnCommand(wrapGMCP("Char.Status", map[string]string{
+		"gold":        "250",
+		"lessons":     "15",
+		"unread_msgs": "1",
+	}))
+
+	assert.Equal(t, []string{
+		"Gold: 250 (+150)",
+		"Lessons: 15 (-15)",
+		"Unread messages: 1 (+1)",
+	}, prints)
+	assert.Equal(t, 2, world.Character.UnreadNews)
+	assert.Equal(t, "Durak", world.Character.Name)
+	assert.Contains(t, character.Sheet, pkg.CharacterDetail{Name: "Gold", Value: "250"})
+}
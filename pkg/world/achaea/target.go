@@ -8,7 +8,6 @@ import (
 	"github.com/tobiassjosten/nogfx/pkg/gmcp"
 	agmcp "github.com/tobiassjosten/nogfx/pkg/gmcp/achaea"
 	igmcp "github.com/tobiassjosten/nogfx/pkg/gmcp/ironrealms"
-	"github.com/tobiassjosten/nogfx/pkg/navigation"
 )
 
 // Target represents who or what is being targeted for skills and attacks.
@@ -16,7 +15,7 @@ type Target struct {
 	*pkg.Target
 	client   pkg.Client
 	isPlayer bool
-	area     *navigation.Area
+	area     int
 }
 
 // NewTarget creates a new target object with the given client.
@@ -48,18 +47,13 @@ func (tgt *Target) Set(name string, _ *pkg.Target) {
 
 // FromRoomInfo handles targeting when moving between rooms (areas, in effect).
 func (tgt *Target) FromRoomInfo(msg *gmcp.RoomInfo) {
-	room := navigation.RoomFromGMCP(msg)
-	if room == nil || room.Area == nil {
+	if msg.AreaNumber == 0 || msg.AreaNumber == tgt.area {
 		return
 	}
 
-	if tgt.area != nil && room.Area.ID == tgt.area.ID {
-		return
-	}
-
-	tgt.area = room.Area
+	tgt.area = msg.AreaNumber
 
-	npcs := tgt.npcs()[room.Area.ID]
+	npcs := tgt.npcs()[msg.AreaNumber]
 	tgt.Target.SetCandidates(npcs)
 }
 
@@ -7,7 +7,6 @@ import (
 	agmcp "github.com/tobiassjosten/nogfx/pkg/gmcp/achaea"
 	igmcp "github.com/tobiassjosten/nogfx/pkg/gmcp/ironrealms"
 	"github.com/tobiassjosten/nogfx/pkg/mock"
-	"github.com/tobiassjosten/nogfx/pkg/navigation"
 	"github.com/tobiassjosten/nogfx/pkg/world/achaea"
 
 	"github.com/icza/gox/gox"
@@ -277,8 +276,6 @@ func TestWorldTargeting(t *testing.T) {
 
 	for name, tc := range tcs {
 		t.Run(name, func(t *testing.T) {
-			navigation.Reset()
-
 			// Default health is different from empty int value.
 			if tc.health == 0 {
 				tc.health = -1
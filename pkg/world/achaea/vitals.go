@@ -0,0 +1,82 @@
+package achaea
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/tobiassjosten/nogfx/pkg"
+)
+
+// vitalDelta is a change of some vital, like health or mana.
+type vitalDelta struct {
+	abbreviation string
+	delta        int
+}
+
+// addVitalDeltas registers changes between the previous and current state of
+// the character, accumulating them until they're summarized.
+func (world *World) addVitalDeltas(previous, current Character) {
+	changes := []vitalDelta{
+		{"hp", current.Health - previous.Health},
+		{"mp", current.Mana - previous.Mana},
+		{"ep", current.Endurance - previous.Endurance},
+		{"wp", current.Willpower - previous.Willpower},
+	}
+
+	for _, change := range changes {
+		if change.delta == 0 {
+			continue
+		}
+
+		exists := false
+
+		for i, delta := range world.deltas {
+			if delta.abbreviation == change.abbreviation {
+				world.deltas[i].delta += change.delta
+				exists = true
+
+				break
+			}
+		}
+
+		if !exists {
+			world.deltas = append(world.deltas, change)
+		}
+	}
+}
+
+// summarizeVitals adds a line summarizing changes to vitals since the last
+// paragraph, like "-432 hp, +120 mp", before the prompt.
+func (world *World) summarizeVitals(inout pkg.Inoutput) pkg.Inoutput {
+	if len(world.deltas) == 0 {
+		return inout
+	}
+
+	parts := []string{}
+
+	for _, delta := range world.deltas {
+		if delta.delta == 0 {
+			continue
+		}
+
+		color := 32
+		if delta.delta < 0 {
+			color = 31
+		}
+
+		parts = append(parts, fmt.Sprintf(
+			"\033[%dm%+d %s\033[0m", color, delta.delta, delta.abbreviation,
+		))
+	}
+
+	world.deltas = nil
+
+	if len(parts) == 0 {
+		return inout
+	}
+
+	summary := []byte(strings.Join(parts, ", "))
+	inout.Output = inout.Output.AddBefore(len(inout.Output)-1, summary)
+
+	return inout
+}
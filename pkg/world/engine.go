@@ -13,11 +13,23 @@ import (
 	"github.com/tobiassjosten/nogfx/pkg/gmcp"
 	"github.com/tobiassjosten/nogfx/pkg/telnet"
 	"github.com/tobiassjosten/nogfx/pkg/world/achaea"
+	"github.com/tobiassjosten/nogfx/pkg/world/generic"
 )
 
-var worlds = map[string]func(pkg.Client, pkg.UI) pkg.World{
-	"achaea.com:23":  achaea.NewWorld,
-	"50.31.100.8:23": achaea.NewWorld,
+// worlds are the games with specific support, by name.
+var worlds = map[string]func(pkg.Client, pkg.UI, *pkg.Config) pkg.World{
+	"achaea":  achaea.NewWorld,
+	"generic": generic.NewWorld,
+}
+
+// The world used for games without specific support, once they've shown that
+// they speak GMCP.
+const fallbackWorld = "generic"
+
+// addresses are the servers of known games, with the names of their worlds.
+var addresses = map[string]string{
+	"achaea.com:23":  "achaea",
+	"50.31.100.8:23": "achaea",
 }
 
 // Engine is the orchestrator of all the cogs of this machinery.
@@ -25,22 +37,77 @@ type Engine struct {
 	client  pkg.Client
 	ui      pkg.UI
 	world   pkg.World
+	config  *pkg.Config
 	address string
+
+	// The session's status, along with what was last shown of it.
+	status  pkg.Status
+	shown   pkg.Status
+	started time.Time
+
+	// Connection health, with when the last ping was sent and whether
+	// it's yet to be answered, and when data was last received.
+	latency  pkg.Latency
+	pinged   time.Time
+	pinging  bool
+	received time.Time
 }
 
-// NewEngine creates a new Engine.
-func NewEngine(client pkg.Client, ui pkg.UI, address string) *Engine {
+// NewEngine creates a new Engine, with the named world or, without a name, the
+// one known to be served from the address.
+func NewEngine(client pkg.Client, ui pkg.UI, config *pkg.Config, address, name string) (*Engine, error) {
+	client = &inspectedClient{Client: client, ui: ui}
+
 	engine := &Engine{
 		client:  client,
 		ui:      ui,
+		config:  config,
 		address: address,
+
+		status: pkg.Status{
+			Address: address,
+			MCCP:    pkg.MCCPOff,
+		},
+		started: time.Now(),
+
+		received: time.Now(),
+	}
+
+	if name == "" {
+		name = addresses[address]
 	}
 
-	if constructor, ok := worlds[address]; ok {
-		engine.world = constructor(client, ui)
+	if name != "" {
+		if err := engine.SetWorld(name); err != nil {
+			return nil, err
+		}
 	}
 
-	return engine
+	return engine, nil
+}
+
+// ValidateName makes sure a world of the given name exists, allowing for an
+// empty one to rely on the address instead.
+func ValidateName(name string) error {
+	if _, ok := worlds[name]; !ok && name != "" {
+		return fmt.Errorf("unknown world '%s'", name)
+	}
+
+	return nil
+}
+
+// SetWorld picks the game-specific support to use by name, instead of relying
+// on the address.
+func (engine *Engine) SetWorld(name string) error {
+	constructor, ok := worlds[name]
+	if !ok {
+		return fmt.Errorf("unknown world '%s'", name)
+	}
+
+	engine.world = constructor(engine.client, engine.ui, engine.config)
+	engine.status.World = name
+
+	return nil
 }
 
 // Run is the main loop of the application, where everything is orchestrated.
@@ -59,15 +126,35 @@ func (engine *Engine) Run(pctx context.Context) error {
 	gamelog := engine.openGamelog(ctx)
 	if gamelog != nil {
 		defer gamelog.Close()
+
+		engine.status.Logfile = gamelog.Name()
 	}
 
+	engine.status.Connected = true
+	engine.syncStatus()
+
+	heartbeat := time.NewTicker(time.Second)
+	defer heartbeat.Stop()
+
 	out := pkg.Exput{}
 
 	for {
+		// Worlds can change while running, so we look for tasks anew.
+		var tasks <-chan func()
+		if tasker, ok := engine.world.(pkg.Tasker); ok {
+			tasks = tasker.Tasks()
+		}
+
 		select {
 		case <-ctx.Done():
 			return nil
 
+		case task := <-tasks:
+			task()
+
+		case now := <-heartbeat.C:
+			engine.Heartbeat(now)
+
 		case err := <-serverErrs:
 			return err
 
@@ -75,6 +162,9 @@ func (engine *Engine) Run(pctx context.Context) error {
 			return err
 
 		case <-serverDone:
+			engine.status.Connected = false
+			engine.syncStatus()
+
 			engine.ui.Outputs() <- []byte("server disconnected")
 
 		case data := <-engine.ui.Inputs():
@@ -88,6 +178,8 @@ func (engine *Engine) Run(pctx context.Context) error {
 			engine.OnInoutput(inout)
 
 		case data := <-serverOutput:
+			engine.receive(time.Now())
+
 			if gamelog != nil {
 				if _, err := gamelog.Write(data); err != nil {
 					log.Printf("failed writing game log: %s", err)
@@ -121,6 +213,9 @@ func (engine *Engine) Run(pctx context.Context) error {
 				continue
 			}
 
+			engine.receive(time.Now())
+			inspect(engine.ui, command, false)
+
 			err := engine.ProcessCommand(command)
 			if err != nil {
 				log.Printf(
@@ -129,9 +224,12 @@ func (engine *Engine) Run(pctx context.Context) error {
 				)
 			}
 
-			inout := engine.world.OnCommand(command)
+			engine.syncStatus()
 
-			engine.OnInoutput(inout)
+			if engine.world != nil {
+				inout := engine.world.OnCommand(command)
+				engine.OnInoutput(inout)
+			}
 		}
 	}
 }
@@ -177,6 +275,12 @@ func (engine *Engine) ProcessCommand(command []byte) error {
 		engine.ui.UnmaskInput()
 
 	case bytes.Equal(command, []byte{telnet.IAC, telnet.WILL, telnet.GMCP}):
+		if engine.world == nil {
+			if err := engine.SetWorld(fallbackWorld); err != nil {
+				return err
+			}
+		}
+
 		err := engine.SendGMCP(&gmcp.CoreHello{
 			Client:  "nogfx",
 			Version: pkg.Version,
@@ -184,11 +288,30 @@ func (engine *Engine) ProcessCommand(command []byte) error {
 		if err != nil {
 			return fmt.Errorf("failed GMCP: %w", err)
 		}
+
+		engine.status.GMCP = true
+
+	case bytes.Equal(command, []byte{telnet.IAC, telnet.WILL, telnet.MCCP2}):
+		engine.status.MCCP = pkg.MCCPDeclined
+	}
+
+	if data := gmcp.Unwrap(command); data != nil {
+		engine.pong(data, time.Now())
 	}
 
 	return nil
 }
 
+// syncStatus passes the session's status on to the UI, whenever it changes.
+func (engine *Engine) syncStatus() {
+	if engine.status == engine.shown {
+		return
+	}
+
+	engine.shown = engine.status
+	engine.ui.SetStatus(engine.status)
+}
+
 // OnInoutput dispatches input and output to the client and UI respectively.
 func (engine *Engine) OnInoutput(inout pkg.Inoutput) {
 	for _, data := range inout.Input.Bytes() {
@@ -3,8 +3,11 @@ package world_test
 import (
 	"errors"
 	"fmt"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/tobiassjosten/nogfx/pkg"
 	"github.com/tobiassjosten/nogfx/pkg/mock"
 	"github.com/tobiassjosten/nogfx/pkg/telnet"
 	"github.com/tobiassjosten/nogfx/pkg/world"
@@ -34,16 +37,18 @@ func wrapGMCP(msgs []string) []byte {
 
 func TestCommandsReply(t *testing.T) {
 	tcs := []struct {
-		command []byte
-		sent    []byte
-		errs    []bool
-		err     string
+		command   []byte
+		sent      []byte
+		inspected []string
+		errs      []bool
+		err       string
 	}{
 		{
 			command: willGMCP,
 			sent: wrapGMCP([]string{
 				`Core.Hello {"client":"nogfx","version":"0.0.0"}`,
 			}),
+			inspected: []string{"Core.Hello"},
 		},
 		{
 			command: []byte{telnet.IAC, telnet.WILL, telnet.GMCP},
@@ -70,11 +75,18 @@ func TestCommandsReply(t *testing.T) {
 				},
 			}
 
-			ui := &mock.UIMock{}
+			var inspected []pkg.GMCPEntry
+
+			ui := &mock.UIMock{
+				AddGMCPFunc: func(entry pkg.GMCPEntry) {
+					inspected = append(inspected, entry)
+				},
+			}
 
-			engine := world.NewEngine(client, ui, "example.com:1337")
+			engine, err := world.NewEngine(client, ui, pkg.NewConfig(), "example.com:1337", "")
+			require.Nil(t, err)
 
-			err := engine.ProcessCommand(tc.command)
+			err = engine.ProcessCommand(tc.command)
 
 			if tc.err != "" && assert.NotNil(t, err) {
 				assert.Equal(t, tc.err, err.Error())
@@ -86,6 +98,13 @@ func TestCommandsReply(t *testing.T) {
 			if len(tc.sent) > 0 {
 				assert.Equal(t, tc.sent, sent, string(sent))
 			}
+
+			require.Len(t, inspected, len(tc.inspected))
+
+			for i, entry := range inspected {
+				assert.True(t, entry.Outgoing)
+				assert.Equal(t, tc.inspected[i], entry.ID)
+			}
 		})
 	}
 }
@@ -107,9 +126,10 @@ func TestMasking(t *testing.T) {
 		},
 	}
 
-	engine := world.NewEngine(client, ui, "example.com:1337")
+	engine, err := world.NewEngine(client, ui, pkg.NewConfig(), "example.com:1337", "")
+	r.Nil(err)
 
-	err := engine.ProcessCommand(willEcho)
+	err = engine.ProcessCommand(willEcho)
 	r.Nil(err)
 
 	a.Equal(true, masked)
@@ -119,3 +139,195 @@ func TestMasking(t *testing.T) {
 
 	a.Equal(false, masked)
 }
+
+func TestHeartbeat(t *testing.T) {
+	var sent []string
+
+	client := &mock.ClientMock{
+		WriteFunc: func(data []byte) (int, error) {
+			sent = append(sent, string(data[3:len(data)-2]))
+			return len(data), nil
+		},
+	}
+
+	var prints []string
+	var latencies []pkg.Latency
+
+	ui := &mock.UIMock{
+		AddGMCPFunc: func(_ pkg.GMCPEntry) {},
+		PrintFunc: func(data []byte) {
+			prints = append(prints, string(data))
+		},
+		SetStatusFunc: func(_ pkg.Status) {},
+		SetLatencyFunc: func(latency pkg.Latency) {
+			latencies = append(latencies, latency)
+		},
+	}
+
+	engine, err := world.NewEngine(client, ui, pkg.NewConfig(), "example.com:1337", "")
+	require.Nil(t, err)
+	now := time.Now()
+
+	// Pings are only sent once the game has agreed to speak GMCP.
+	engine.Heartbeat(now)
+	assert.Empty(t, sent)
+
+	require.Nil(t, engine.ProcessCommand(willGMCP))
+	sent = nil
+
+	engine.Heartbeat(now)
+	assert.Equal(t, []string{"Core.Ping"}, sent)
+
+	require.Nil(t, engine.ProcessCommand(wrapGMCP([]string{"Core.Ping"})))
+	require.Len(t, latencies, 1)
+	assert.Len(t, latencies[0].History, 1)
+
+	// Replies without a ping of ours are ignored.
+	require.Nil(t, engine.ProcessCommand(wrapGMCP([]string{"Core.Ping"})))
+	assert.Len(t, latencies, 1)
+
+	engine.Heartbeat(now.Add(time.Second))
+	assert.Len(t, sent, 1)
+
+	engine.Heartbeat(now.Add(30 * time.Second))
+	require.Len(t, sent, 2)
+	assert.True(t, strings.HasPrefix(sent[1], "Core.Ping"))
+
+	assert.Empty(t, prints)
+
+	engine.Heartbeat(now.Add(2 * time.Minute))
+	engine.Heartbeat(now.Add(2*time.Minute + time.Second))
+
+	assert.Equal(t, []string{"No data from the server in 2m0s."}, prints)
+	assert.Equal(t, 2*time.Minute+time.Second, latencies[len(latencies)-1].Silence)
+}
+
+func TestStatus(t *testing.T) {
+	client := &mock.ClientMock{
+		WriteFunc: func(data []byte) (int, error) {
+			return len(data), nil
+		},
+	}
+
+	var status pkg.Status
+
+	ui := &mock.UIMock{
+		AddGMCPFunc:    func(_ pkg.GMCPEntry) {},
+		PrintFunc:      func(_ []byte) {},
+		SetLatencyFunc: func(_ pkg.Latency) {},
+		SetStatusFunc: func(s pkg.Status) {
+			status = s
+		},
+	}
+
+	engine, err := world.NewEngine(client, ui, pkg.NewConfig(), "achaea.com:23", "")
+	require.Nil(t, err)
+
+	require.Nil(t, engine.ProcessCommand(willGMCP))
+	require.Nil(t, engine.ProcessCommand([]byte{telnet.IAC, telnet.WILL, telnet.MCCP2}))
+
+	engine.Heartbeat(time.Now().Add(65*time.Minute + 30*time.Second))
+
+	assert.Equal(t, pkg.Status{
+		Address:  "achaea.com:23",
+		World:    "achaea",
+		Duration: 65 * time.Minute,
+		GMCP:     true,
+		MCCP:     pkg.MCCPDeclined,
+	}, status)
+}
+
+func TestWorldSelection(t *testing.T) {
+	tcs := map[string]struct {
+		address string
+		world   string
+		gmcp    bool
+		status  string
+		err     string
+	}{
+		"known address": {
+			address: "achaea.com:23",
+			status:  "achaea",
+		},
+
+		"unknown address": {
+			address: "example.com:1337",
+			status:  "",
+		},
+
+		"unknown address with GMCP": {
+			address: "example.com:1337",
+			gmcp:    true,
+			status:  "generic",
+		},
+
+		"chosen": {
+			address: "example.com:1337",
+			world:   "achaea",
+			gmcp:    true,
+			status:  "achaea",
+		},
+
+		"chosen over address": {
+			address: "achaea.com:23",
+			world:   "generic",
+			status:  "generic",
+		},
+
+		"unknown": {
+			address: "example.com:1337",
+			world:   "asdf",
+			err:     "unknown world 'asdf'",
+		},
+	}
+
+	for name, tc := range tcs {
+		t.Run(name, func(t *testing.T) {
+			client := &mock.ClientMock{
+				WriteFunc: func(data []byte) (int, error) {
+					return len(data), nil
+				},
+			}
+
+			var status pkg.Status
+
+			ui := &mock.UIMock{
+				AddGMCPFunc:    func(_ pkg.GMCPEntry) {},
+				SetLatencyFunc: func(_ pkg.Latency) {},
+				SetStatusFunc: func(s pkg.Status) {
+					status = s
+				},
+			}
+
+			engine, err := world.NewEngine(
+				client, ui, pkg.NewConfig(), tc.address, tc.world,
+			)
+
+			if tc.err != "" {
+				require.NotNil(t, err)
+				assert.Equal(t, tc.err, err.Error())
+				return
+			}
+
+			require.Nil(t, err)
+
+			if tc.gmcp {
+				require.Nil(t, engine.ProcessCommand(willGMCP))
+			}
+
+			engine.Heartbeat(time.Now())
+
+			assert.Equal(t, tc.status, status.World)
+		})
+	}
+}
+
+func TestValidateName(t *testing.T) {
+	assert.Nil(t, world.ValidateName("achaea"))
+	assert.Nil(t, world.ValidateName("generic"))
+	assert.Nil(t, world.ValidateName(""))
+
+	err := world.ValidateName("asdf")
+	require.NotNil(t, err)
+	assert.Equal(t, "unknown world 'asdf'", err.Error())
+}
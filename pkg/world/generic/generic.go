@@ -0,0 +1,160 @@
+package generic
+
+import (
+	"bytes"
+	"fmt"
+	"log"
+
+	"github.com/tobiassjosten/nogfx/pkg"
+	"github.com/tobiassjosten/nogfx/pkg/gmcp"
+	igmcp "github.com/tobiassjosten/nogfx/pkg/gmcp/ironrealms"
+	"github.com/tobiassjosten/nogfx/pkg/navigation"
+	"github.com/tobiassjosten/nogfx/pkg/telnet"
+	gmodule "github.com/tobiassjosten/nogfx/pkg/world/module"
+)
+
+// World is an implementation of the pkg.World interface for any game speaking
+// GMCP, supporting the common messages and the Iron Realms extensions to them.
+type World struct {
+	client pkg.Client
+	ui     pkg.UI
+
+	triggers []pkg.Trigger
+
+	Character pkg.Character
+	Map       *navigation.Map
+	Room      *navigation.Room
+	Target    *pkg.Target
+}
+
+// NewWorld creates a new generic pkg.World.
+func NewWorld(client pkg.Client, ui pkg.UI, _ *pkg.Config) pkg.World {
+	world := &World{
+		client: client,
+		ui:     ui,
+
+		Character: pkg.Character{Vitals: map[string]pkg.CharacterVital{}},
+		Map:       navigation.NewMap(),
+	}
+
+	world.Target = pkg.NewTarget(world.setTarget)
+
+	var modules = []pkg.Module{
+		gmodule.NewRepeatInput(),
+	}
+
+	for _, module := range modules {
+		world.triggers = append(world.triggers, module.Triggers()...)
+	}
+
+	return world
+}
+
+// AddTrigger adds a trigger to those run on input, output or GMCP messages.
+func (world *World) AddTrigger(trigger pkg.Trigger) {
+	world.triggers = append(world.triggers, trigger)
+}
+
+// OnInoutput reacts to player input and server output.
+func (world *World) OnInoutput(inout pkg.Inoutput) pkg.Inoutput {
+	for _, trigger := range world.triggers {
+		if trigger.Kind == pkg.Input && len(inout.Input) > 0 {
+			inout = trigger.Match(inout.Input.Bytes(), inout)
+		}
+
+		if trigger.Kind == pkg.Output && len(inout.Output) > 0 {
+			inout = trigger.Match(inout.Output.Bytes(), inout)
+		}
+	}
+
+	return inout
+}
+
+// OnCommand reacts to telnet commands.
+func (world *World) OnCommand(cmd []byte) (inout pkg.Inoutput) {
+	if data := gmcp.Unwrap(cmd); data != nil {
+		if err := world.onGMCP(data); err != nil {
+			log.Printf("failed processing gmcp: %s", err)
+		}
+
+		for _, trigger := range world.triggers {
+			if trigger.Kind == pkg.GMCP {
+				inout = trigger.MatchGMCP(data, inout)
+			}
+		}
+
+		return
+	}
+
+	if bytes.Equal(cmd, []byte{telnet.IAC, telnet.WILL, telnet.GMCP}) {
+		err := world.SendGMCP(&gmcp.CoreSupportsSet{
+			"Char":       1,
+			"Room":       1,
+			"IRE.Target": 1,
+		})
+		if err != nil {
+			log.Printf("failed sending gmcp: %s", err)
+		}
+	}
+
+	return
+}
+
+func (world *World) onGMCP(data []byte) error {
+	message, err := igmcp.Parse(data)
+	if err != nil {
+		return fmt.Errorf("failed parsing GMCP: %w", err)
+	}
+
+	switch msg := message.(type) {
+	case *gmcp.RawMessage:
+		// Games have their own takes on Char.Vitals, so we make do with
+		// whatever pairs of values and maximums they send.
+		if msg.ID() == "Char.Vitals" {
+			world.Character.Vitals = vitals(msg.Data)
+			world.ui.SetCharacter(world.Character)
+		}
+
+	case *gmcp.RoomInfo:
+		if world.Room != nil {
+			world.Room.HasPlayer = false
+		}
+
+		world.Room = world.Map.RoomFromGMCP(msg)
+		world.Room.HasPlayer = true
+
+		world.ui.SetMap(world.Map)
+		world.ui.SetRoom(world.Room)
+
+	case *igmcp.IRETargetSet:
+		if msg.Target != world.Target.Name {
+			world.Target.Health = -1
+		}
+
+		world.Target.Name = msg.Target
+		world.ui.SetTarget(world.Target)
+
+	case *igmcp.IRETargetInfo:
+		world.Target.Health = msg.Health
+		world.ui.SetTarget(world.Target)
+	}
+
+	return nil
+}
+
+// setTarget tells the game what to target.
+func (world *World) setTarget(name string, _ *pkg.Target) {
+	if err := world.SendGMCP(&igmcp.IRETargetSet{Target: name}); err != nil {
+		log.Printf("failed sending gmcp: %s", err)
+	}
+}
+
+// SendGMCP writes a GMCP message to the client.
+func (world *World) SendGMCP(msg gmcp.Message) error {
+	data := gmcp.Wrap([]byte(msg.Marshal()))
+	if _, err := world.client.Write(data); err != nil {
+		return err
+	}
+
+	return nil
+}
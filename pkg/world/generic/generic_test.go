@@ -0,0 +1,168 @@
+package generic_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/tobiassjosten/nogfx/pkg"
+	"github.com/tobiassjosten/nogfx/pkg/mock"
+	"github.com/tobiassjosten/nogfx/pkg/navigation"
+	"github.com/tobiassjosten/nogfx/pkg/telnet"
+	"github.com/tobiassjosten/nogfx/pkg/tui"
+	"github.com/tobiassjosten/nogfx/pkg/world/generic"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func wrapGMCP(msg string, data any) (bs []byte) {
+	content := []byte(msg)
+
+	if data != nil {
+		mdata, _ := json.Marshal(data)
+		content = append(content, append([]byte{' '}, mdata...)...)
+	}
+
+	bs = append(bs, telnet.IAC, telnet.SB, telnet.GMCP)
+	bs = append(bs, content...)
+	bs = append(bs, telnet.IAC, telnet.SE)
+
+	return bs
+}
+
+func TestSupports(t *testing.T) {
+	var writes []string
+
+	client := &mock.ClientMock{
+		WriteFunc: func(data []byte) (int, error) {
+			writes = append(writes, string(data[3:len(data)-2]))
+			return len(data), nil
+		},
+	}
+
+	world := generic.NewWorld(client, &mock.UIMock{}, pkg.NewConfig())
+	world.OnCommand([]byte{telnet.IAC, telnet.WILL, telnet.GMCP})
+
+	require.Len(t, writes, 1)
+	assert.Equal(t, `Core.Supports.Set ["Char 1","IRE.Target 1","Room 1"]`, writes[0])
+}
+
+func TestVitals(t *testing.T) {
+	tcs := map[string]struct {
+		data   any
+		vitals map[string]pkg.CharacterVital
+	}{
+		"strings": {
+			data: map[string]string{
+				"hp": "900", "maxhp": "1000",
+				"mp": "80", "maxmp": "100",
+				"string": "h:900 m:80",
+			},
+			vitals: map[string]pkg.CharacterVital{
+				"health": {Value: 900, Max: 1000},
+				"mana":   {Value: 80, Max: 100},
+			},
+		},
+
+		"numbers": {
+			data: map[string]int{
+				"ep": 30, "maxep": 40,
+				"rage": 12, "maxrage": 100,
+				"lonely": 3,
+			},
+			vitals: map[string]pkg.CharacterVital{
+				"endurance": {Value: 30, Max: 40},
+				"rage":      {Value: 12, Max: 100},
+			},
+		},
+
+		"not an object": {
+			data:   []int{1, 2},
+			vitals: map[string]pkg.CharacterVital{},
+		},
+	}
+
+	for name, tc := range tcs {
+		t.Run(name, func(t *testing.T) {
+			var character pkg.Character
+
+			ui := &mock.UIMock{
+				SetCharacterFunc: func(c pkg.Character) {
+					character = c
+				},
+			}
+
+			world := generic.NewWorld(&mock.ClientMock{}, ui, pkg.NewConfig())
+			world.OnCommand(wrapGMCP("Char.Vitals", tc.data))
+
+			assert.Equal(t, tc.vitals, character.Vitals)
+		})
+	}
+}
+
+func TestRoomInfo(t *testing.T) {
+	var room *navigation.Room
+
+	ui := &mock.UIMock{
+		SetMapFunc: func(_ *navigation.Map) {},
+		SetRoomFunc: func(r *navigation.Room) {
+			room = r
+		},
+	}
+
+	world := generic.NewWorld(&mock.ClientMock{}, ui, pkg.NewConfig())
+	world.OnCommand(wrapGMCP("Room.Info", map[string]any{
+		"num":  1234,
+		"name": "A dusty road",
+		"area": "The Plains",
+	}))
+
+	require.NotNil(t, room)
+	assert.Equal(t, 1234, room.ID)
+	assert.Equal(t, "A dusty road", room.Name)
+	assert.True(t, room.HasPlayer)
+
+	// The minimap marks where the player is.
+	rows := tui.RenderMap(room, 5, 3, tui.DefaultTheme())
+	assert.Equal(t, "[+]", rows[1][1:4].String())
+
+	previous := room
+	world.OnCommand(wrapGMCP("Room.Info", map[string]any{
+		"num":  1235,
+		"name": "A dustier road",
+		"area": "The Plains",
+	}))
+
+	assert.Equal(t, 1235, room.ID)
+	assert.True(t, room.HasPlayer)
+	assert.False(t, previous.HasPlayer)
+}
+
+func TestTarget(t *testing.T) {
+	var target *pkg.Target
+
+	ui := &mock.UIMock{
+		SetTargetFunc: func(tgt *pkg.Target) {
+			target = tgt
+		},
+	}
+
+	world := generic.NewWorld(&mock.ClientMock{}, ui, pkg.NewConfig())
+
+	world.OnCommand(wrapGMCP("IRE.Target.Set", "rat"))
+	require.NotNil(t, target)
+	assert.Equal(t, "rat", target.Name)
+	assert.Equal(t, -1, target.Health)
+
+	world.OnCommand(wrapGMCP("IRE.Target.Info", map[string]string{
+		"id": "1234", "short_desc": "a rat", "hpperc": "75%",
+	}))
+	assert.Equal(t, 75, target.Health)
+
+	world.OnCommand(wrapGMCP("IRE.Target.Set", "rat"))
+	assert.Equal(t, 75, target.Health)
+
+	world.OnCommand(wrapGMCP("IRE.Target.Set", ""))
+	assert.Equal(t, "", target.Name)
+	assert.Equal(t, -1, target.Health)
+}
@@ -0,0 +1,66 @@
+package generic
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/tobiassjosten/nogfx/pkg"
+)
+
+// vitalNames translates the common abbreviations of vitals.
+var vitalNames = map[string]string{
+	"hp": "health",
+	"mp": "mana",
+	"ep": "endurance",
+	"wp": "willpower",
+}
+
+// vitals finds the values with matching maximums, like "hp" and "maxhp", in a
+// decoded Char.Vitals message.
+func vitals(data any) map[string]pkg.CharacterVital {
+	vitals := map[string]pkg.CharacterVital{}
+
+	values, ok := data.(map[string]any)
+	if !ok {
+		return vitals
+	}
+
+	for key, value := range values {
+		if strings.HasPrefix(key, "max") {
+			continue
+		}
+
+		current, ok := number(value)
+		if !ok {
+			continue
+		}
+
+		max, ok := number(values["max"+key])
+		if !ok || max <= 0 {
+			continue
+		}
+
+		name := key
+		if alias, ok := vitalNames[key]; ok {
+			name = alias
+		}
+
+		vitals[name] = pkg.CharacterVital{Value: current, Max: max}
+	}
+
+	return vitals
+}
+
+// number reads an integer sent either as a JSON number or as a string.
+func number(value any) (int, bool) {
+	switch value := value.(type) {
+	case float64:
+		return int(value), true
+
+	case string:
+		i, err := strconv.Atoi(value)
+		return i, err == nil
+	}
+
+	return 0, false
+}
@@ -0,0 +1,30 @@
+package world
+
+import (
+	"github.com/tobiassjosten/nogfx/pkg"
+	"github.com/tobiassjosten/nogfx/pkg/gmcp"
+)
+
+// inspectedClient passes the GMCP messages written to the game on to the UI,
+// for inspection, whether they're sent by the engine or the world.
+type inspectedClient struct {
+	pkg.Client
+	ui pkg.UI
+}
+
+// Write sends data to the game and records it if it's a GMCP message.
+func (client *inspectedClient) Write(data []byte) (int, error) {
+	count, err := client.Client.Write(data)
+	if err == nil {
+		inspect(client.ui, data, true)
+	}
+
+	return count, err
+}
+
+// inspect records a telnet command for inspection, if it's a GMCP message.
+func inspect(ui pkg.UI, command []byte, outgoing bool) {
+	if data := gmcp.Unwrap(command); data != nil {
+		ui.AddGMCP(pkg.NewGMCPEntry(data, outgoing))
+	}
+}
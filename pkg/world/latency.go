@@ -0,0 +1,81 @@
+package world
+
+import (
+	"bytes"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/tobiassjosten/nogfx/pkg/gmcp"
+)
+
+// How many round-trip times to keep, for the history shown in the UI.
+const latencyHistory = 60
+
+// Heartbeat pings the game at the configured interval, to measure latency, and
+// warns when it's gone without sending anything for too long. It also keeps
+// the session duration up to date.
+func (engine *Engine) Heartbeat(now time.Time) {
+	engine.status.Duration = now.Sub(engine.started).Truncate(time.Minute)
+	engine.syncStatus()
+
+	config := engine.config.Latency
+
+	if engine.status.GMCP && now.Sub(engine.pinged) >= config.PingInterval() {
+		msg := &gmcp.CorePing{}
+
+		// The game is told of our average latency, as a courtesy.
+		if average := engine.latency.Average(); average > 0 {
+			ms := int(average.Milliseconds())
+			msg.Latency = &ms
+		}
+
+		if err := engine.SendGMCP(msg); err != nil {
+			log.Printf("failed sending ping: %s", err)
+		}
+
+		engine.pinged = now
+		engine.pinging = true
+	}
+
+	silence := now.Sub(engine.received)
+	if silence < config.SilenceLimit() {
+		return
+	}
+
+	if engine.latency.Silence == 0 {
+		engine.ui.Print([]byte(fmt.Sprintf(
+			"No data from the server in %s.", silence.Round(time.Second),
+		)))
+	}
+
+	engine.latency.Silence = silence.Round(time.Second)
+	engine.ui.SetLatency(engine.latency)
+}
+
+// receive notes that the game sent something, to clear any silence warning.
+func (engine *Engine) receive(now time.Time) {
+	engine.received = now
+
+	if engine.latency.Silence > 0 {
+		engine.latency.Silence = 0
+		engine.ui.SetLatency(engine.latency)
+	}
+}
+
+// pong measures the round-trip time when the game replies to our ping.
+func (engine *Engine) pong(data []byte, now time.Time) {
+	id, _, _ := bytes.Cut(data, []byte{' '})
+	if !engine.pinging || string(id) != (&gmcp.CorePing{}).ID() {
+		return
+	}
+
+	engine.pinging = false
+
+	engine.latency.History = append(engine.latency.History, now.Sub(engine.pinged))
+	if len(engine.latency.History) > latencyHistory {
+		engine.latency.History = engine.latency.History[1:]
+	}
+
+	engine.ui.SetLatency(engine.latency)
+}